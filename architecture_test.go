@@ -26,11 +26,12 @@ func TestArchitectureDependencies(t *testing.T) {
 		source string
 		target string
 	}{
-		// command, util, db, models have no internal imports
+		// command, util, db, models, dice have no internal imports
 		{"command has no internal imports", `^command$`, module},
 		{"util has no internal imports", `^util$`, module},
 		{"db has no internal imports", `^db$`, module},
 		{"models has no internal imports", `^models$`, module},
+		{"dice has no internal imports", `^dice$`, module},
 
 		// repository must not import command or ui
 		{"repository does not import command", `^repository$`, module + `command`},
@@ -17,6 +17,11 @@ const (
 	ReaderScreenIndex
 	ProfileScreenIndex
 	NoteScreenIndex
+	SessionLogScreenIndex
+	ClassScreenIndex
+	ConditionScreenIndex
+	RollLogScreenIndex
+	MacroScreenIndex
 )
 
 type Direction int
@@ -54,6 +59,16 @@ func WriteBackRequest() tea.Msg {
 	return WriteBackRequestMsg{}
 }
 
+type SwitchProfileRequestMsg struct {
+	Name string
+}
+
+func SwitchProfileRequest(name string) func() tea.Msg {
+	return func() tea.Msg {
+		return SwitchProfileRequestMsg{name}
+	}
+}
+
 type LoadSummariesRequestMsg struct{}
 
 func LoadSummariesRequest() tea.Msg {
@@ -70,6 +85,18 @@ func SelectCharacterCmd(id uuid.UUID) func() tea.Msg {
 	}
 }
 
+// RunQuickActionMsg runs the input in the quick action palette, e.g. to roll
+// a check from a row.
+type RunQuickActionMsg struct {
+	Input string
+}
+
+func RunQuickActionCmd(input string) func() tea.Msg {
+	return func() tea.Msg {
+		return RunQuickActionMsg{input}
+	}
+}
+
 type SwitchScreenMsg struct {
 	Screen ScreenIndex
 }
@@ -4,8 +4,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/duckdb/duckdb-go/v2"
@@ -23,7 +27,7 @@ func Open(dbPath string) (*sqlx.DB, error) {
 
 	db, err := sqlx.Open("duckdb", dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("db.Open: open duckdb: %w", err)
+		return nil, fmt.Errorf("db.Open: open duckdb: %w", lockError(dbPath, err))
 	}
 
 	db.SetMaxOpenConns(1)
@@ -32,11 +36,200 @@ func Open(dbPath string) (*sqlx.DB, error) {
 
 	if err := ping(db.DB); err != nil {
 		_ = db.Close()
-		return nil, err
+		return nil, lockError(dbPath, err)
 	}
 	return db, nil
 }
 
+// Opens an existing duckdb at given path in read-only access mode. Writes fail
+// with a driver error, and other processes may open the file read-only as well.
+func OpenReadOnly(dbPath string) (*sqlx.DB, error) {
+	if dbPath == "" {
+		return nil, errors.New("db.OpenReadOnly: empty database path")
+	}
+
+	db, err := sqlx.Open("duckdb", dbPath+"?access_mode=read_only")
+	if err != nil {
+		return nil, fmt.Errorf("db.OpenReadOnly: open duckdb: %w", lockError(dbPath, err))
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxIdleTime(10 * time.Minute)
+
+	if err := ping(db.DB); err != nil {
+		_ = db.Close()
+		return nil, lockError(dbPath, err)
+	}
+	return db, nil
+}
+
+// Attempts of Snapshot to get a consistent copy of a database being written.
+const (
+	snapshotAttempts   = 3
+	snapshotRetryDelay = 200 * time.Millisecond
+)
+
+// Snapshot copies the database into a temporary directory so it can be
+// opened read-only while another process holds the lock on the original.
+// DuckDB does not let a second process open the locked file, so the file and
+// its write-ahead log are copied as they are. The copy is then attached and
+// copied into a fresh database with COPY FROM DATABASE, which replays the log
+// and fails on a copy torn by a concurrent write, in which case it is
+// retried. The returned cleanup removes the snapshot.
+func Snapshot(dbPath string) (string, func(), error) {
+	tmp, err := os.MkdirTemp("", "dnc_snapshot_*")
+	if err != nil {
+		return "", func() {}, fmt.Errorf("db.Snapshot: %w", err)
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(tmp)
+	}
+	rawPath := filepath.Join(tmp, "raw_"+filepath.Base(dbPath))
+	snapPath := filepath.Join(tmp, filepath.Base(dbPath))
+	for attempt := 1; ; attempt++ {
+		if err = copyWithWAL(dbPath, rawPath); err == nil {
+			err = copyDatabase(rawPath, snapPath)
+		}
+		removeWithWAL(rawPath)
+		if err == nil {
+			return snapPath, cleanup, nil
+		}
+		removeWithWAL(snapPath)
+		if attempt == snapshotAttempts {
+			cleanup()
+			return "", func() {}, fmt.Errorf("db.Snapshot: %w", err)
+		}
+		time.Sleep(snapshotRetryDelay)
+	}
+}
+
+// MigratedSnapshot is a Snapshot with all migrations applied, so that a
+// database on an older schema, e.g. a backup, can be read without changing
+// it. The returned cleanup removes the snapshot.
+func MigratedSnapshot(dbPath string) (string, func(), error) {
+	path, cleanup, err := Snapshot(dbPath)
+	if err != nil {
+		return "", func() {}, err
+	}
+	handle, err := Open(path)
+	if err == nil {
+		err = MigrateUp(handle)
+		if cerr := handle.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		cleanup()
+		return "", func() {}, fmt.Errorf("db.MigratedSnapshot: %w", err)
+	}
+	return path, cleanup, nil
+}
+
+// copyWithWAL copies the database file and its write-ahead log if there is
+// one.
+func copyWithWAL(src, dst string) error {
+	if err := copyFile(src, dst); err != nil {
+		return err
+	}
+	err := copyFile(src+".wal", dst+".wal")
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func removeWithWAL(path string) {
+	_ = os.Remove(path)
+	_ = os.Remove(path + ".wal")
+}
+
+// copyDatabase copies every table of the database at src into a new database
+// at dst.
+func copyDatabase(src, dst string) error {
+	mem, err := sqlx.Open("duckdb", "")
+	if err != nil {
+		return err
+	}
+	defer mem.Close()
+	mem.SetMaxOpenConns(1)
+	for _, stmt := range []string{
+		fmt.Sprintf(`ATTACH %s AS src`, quote(src)),
+		fmt.Sprintf(`ATTACH %s AS dst`, quote(dst)),
+		`COPY FROM DATABASE src TO dst`,
+		`DETACH dst`,
+		`DETACH src`,
+	} {
+		if _, err := mem.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quote makes a string literal of a path.
+func quote(path string) string {
+	return "'" + strings.ReplaceAll(path, "'", "''") + "'"
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// LockedError is returned by Open and OpenReadOnly if another process holds
+// the lock on the database file.
+type LockedError struct {
+	Path string
+	// Holder is the executable holding the lock, PID its process id. Both
+	// are zero if DuckDB did not report them.
+	Holder string
+	PID    int
+	Err    error
+}
+
+func (e *LockedError) Error() string {
+	if e.PID == 0 {
+		return fmt.Sprintf("database %s is locked by another process", e.Path)
+	}
+	return fmt.Sprintf("database %s is locked by %s (PID %d)", e.Path, e.Holder, e.PID)
+}
+
+func (e *LockedError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	rxLock       = regexp.MustCompile(`Could not set lock on file`)
+	rxLockHolder = regexp.MustCompile(`Conflicting lock is held in (.*) \(PID (\d+)\)`)
+)
+
+// lockError turns DuckDB's lock conflict error into a LockedError and passes
+// any other error through.
+func lockError(dbPath string, err error) error {
+	if err == nil || !rxLock.MatchString(err.Error()) {
+		return err
+	}
+	locked := &LockedError{Path: dbPath, Err: err}
+	if m := rxLockHolder.FindStringSubmatch(err.Error()); m != nil {
+		locked.Holder = m[1]
+		locked.PID, _ = strconv.Atoi(m[2])
+	}
+	return locked
+}
+
 func ping(sdb *sql.DB) error {
 	if err := sdb.Ping(); err != nil {
 		return fmt.Errorf("db.Open: ping: %w", err)
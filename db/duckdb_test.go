@@ -0,0 +1,62 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLockErrorNamesHolder(t *testing.T) {
+	raw := errors.New(`IO Error: Could not set lock on file "/tmp/dnc.db": Conflicting lock is held in /usr/local/bin/dnc (PID 4242). See also https://duckdb.org/docs/stable/connect/concurrency`)
+	var locked *LockedError
+	if !errors.As(lockError("/tmp/dnc.db", raw), &locked) {
+		t.Fatalf("Expected a LockedError")
+	}
+	if locked.Holder != "/usr/local/bin/dnc" || locked.PID != 4242 {
+		t.Errorf("Unexpected holder: %s (PID %d)", locked.Holder, locked.PID)
+	}
+	if !errors.Is(locked, raw) {
+		t.Errorf("Expected the driver error to be wrapped")
+	}
+
+	other := errors.New("IO Error: Cannot open file")
+	if err := lockError("/tmp/dnc.db", other); err != other {
+		t.Errorf("Expected other errors to pass through, got %v", err)
+	}
+}
+
+func TestSnapshotOfOpenDatabase(t *testing.T) {
+	dbPath := TestDBPath()
+	handle, err := TestDBInstance(dbPath)
+	if err != nil {
+		t.Fatalf("Could not create test DB: %s", err.Error())
+	}
+	defer func() {
+		if err := DestroyTestDB(handle, dbPath); err != nil {
+			t.Fatalf("Could not destroy test DB: %s", err.Error())
+		}
+	}()
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+	if _, err := handle.Exec(`INSERT INTO skill_definition (id, name, ability) VALUES (99, 'Juggling', 'Dexterity')`); err != nil {
+		t.Fatalf("Could not insert a skill: %s", err.Error())
+	}
+
+	snapPath, cleanup, err := Snapshot(dbPath)
+	if err != nil {
+		t.Fatalf("Could not snapshot the open DB: %s", err.Error())
+	}
+	defer cleanup()
+	snap, err := OpenReadOnly(snapPath)
+	if err != nil {
+		t.Fatalf("Could not open the snapshot: %s", err.Error())
+	}
+	defer snap.Close()
+	var name string
+	if err := snap.Get(&name, `SELECT name FROM skill_definition WHERE id = 99`); err != nil {
+		t.Fatalf("Could not read from the snapshot: %s", err.Error())
+	}
+	if name != "Juggling" {
+		t.Errorf("Expected the latest commit in the snapshot, got %q", name)
+	}
+}
@@ -58,6 +58,28 @@ func MigrateUp(db *sqlx.DB) error {
 	return nil
 }
 
+// ErrPendingMigrations is returned by CheckMigrated if the schema is outdated.
+var ErrPendingMigrations = errors.New("database schema is outdated")
+
+// CheckMigrated verifies that all migrations have been applied without
+// modifying the database. Used where MigrateUp cannot run, e.g. read-only.
+func CheckMigrated(db *sqlx.DB) error {
+	applied, err := loadAppliedVersions(db)
+	if err != nil {
+		return err
+	}
+	list, err := listMigrationFiles()
+	if err != nil {
+		return err
+	}
+	for _, mf := range list {
+		if !applied[mf.version] {
+			return fmt.Errorf("db.CheckMigrated: %w (missing %s)", ErrPendingMigrations, mf.name)
+		}
+	}
+	return nil
+}
+
 // MigrateDown rolls back all applied migrations in reverse order.
 func MigrateDown(db *sqlx.DB) error {
 	applied, err := orderedAppliedVersions(db)
@@ -1,8 +1,13 @@
 package db
 
 import (
+	"database/sql"
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/jmoiron/sqlx"
 )
 
 func TestEmptyMigrations(t *testing.T) {
@@ -138,3 +143,387 @@ func TestExtractSectionsRejectMalformed(t *testing.T) {
 		}
 	})
 }
+
+func TestReadOnlyRequiresMigratedSchema(t *testing.T) {
+	dbPath := TestDBPath()
+	handle, err := TestDBInstance(dbPath)
+	if err != nil {
+		t.Fatalf("Could not create test DB: %s", err.Error())
+	}
+	if err := ensureMigrationTable(handle); err != nil {
+		t.Fatalf("Could not create migration table: %s", err.Error())
+	}
+	if err := CheckMigrated(handle); !errors.Is(err, ErrPendingMigrations) {
+		t.Errorf("Expected pending migrations, got: %v", err)
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+	if err := handle.Close(); err != nil {
+		t.Fatalf("Could not close test DB: %s", err.Error())
+	}
+
+	ro, err := OpenReadOnly(dbPath)
+	if err != nil {
+		t.Fatalf("Could not open test DB read-only: %s", err.Error())
+	}
+	defer func() {
+		if err := DestroyTestDB(ro, dbPath); err != nil {
+			t.Fatalf("Could not destroy test DB: %s", err.Error())
+		}
+	}()
+	if err := CheckMigrated(ro); err != nil {
+		t.Errorf("Expected migrated schema, got: %s", err.Error())
+	}
+	if _, err := ro.Exec(`INSERT INTO skill_definition (id, name, ability) VALUES (99, 'Juggling', 'Dexterity')`); err == nil {
+		t.Errorf("Expected a write to a read-only DB to fail")
+	}
+}
+
+func TestMigratedSnapshot(t *testing.T) {
+	handle := newTestDBAt(t, 9)
+	path, cleanup, err := MigratedSnapshot(TestDBPath())
+	if err != nil {
+		t.Fatalf("Could not snapshot the DB: %s", err.Error())
+	}
+	defer cleanup()
+
+	ro, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("Could not open the snapshot read-only: %s", err.Error())
+	}
+	defer ro.Close()
+	if err := CheckMigrated(ro); err != nil {
+		t.Errorf("Expected a migrated snapshot, got: %s", err.Error())
+	}
+	if err := CheckMigrated(handle); !errors.Is(err, ErrPendingMigrations) {
+		t.Errorf("Expected the original to stay outdated, got: %v", err)
+	}
+}
+
+// newTestDBAt creates a test DB with all migrations below version applied.
+func newTestDBAt(t *testing.T, version int) *sqlx.DB {
+	t.Helper()
+	dbPath := TestDBPath()
+	handle, err := TestDBInstance(dbPath)
+	if err != nil {
+		t.Fatalf("Could not create test DB: %s", err.Error())
+	}
+	t.Cleanup(func() {
+		if err := DestroyTestDB(handle, dbPath); err != nil {
+			t.Fatalf("Could not destroy test DB: %s", err.Error())
+		}
+	})
+	if err := ensureMigrationTable(handle); err != nil {
+		t.Fatalf("Could not create migration table: %s", err.Error())
+	}
+	list, err := listMigrationFiles()
+	if err != nil {
+		t.Fatalf("Could not list migrations: %s", err.Error())
+	}
+	for _, mf := range list {
+		if mf.version >= version {
+			break
+		}
+		upSQL, _, err := loadMigrationSections(mf.name)
+		if err != nil {
+			t.Fatalf("Could not load %s: %s", mf.name, err.Error())
+		}
+		if err := applyUp(handle, mf.version, mf.name, upSQL); err != nil {
+			t.Fatalf("Could not apply %s: %s", mf.name, err.Error())
+		}
+	}
+	return handle
+}
+
+func TestClassLevelsMigration(t *testing.T) {
+	handle := newTestDBAt(t, 9)
+	if _, err := handle.Exec(`
+		INSERT INTO character (name, class_levels, proficiency_bonus, armor_class, initiative, speed,
+			max_hit_points, curr_hit_points, spell_slots, spell_slots_used)
+		VALUES ('Bobby', 'Wizard 5 / Cleric (Life) 2, Level 1 warlock', 0, 0, 0, 0, 0, 0,
+			[0,0,0,0,0,0,0,0,0,0], [0,0,0,0,0,0,0,0,0,0])`); err != nil {
+		t.Fatalf("Could not insert a character: %s", err.Error())
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+
+	type class struct {
+		Name         string `db:"class_name"`
+		Subclass     string `db:"subclass"`
+		Level        int    `db:"level"`
+		HitDie       int    `db:"hit_die"`
+		Spellcasting int    `db:"spellcasting"`
+	}
+	var got []class
+	if err := handle.Select(&got, `SELECT class_name, subclass, level, hit_die, spellcasting FROM character_class ORDER BY level DESC, class_name`); err != nil {
+		t.Fatalf("Could not read classes: %s", err.Error())
+	}
+	want := []class{
+		{"Wizard", "", 5, 6, 1},
+		{"Cleric", "Life", 2, 8, 1},
+		{"warlock", "", 1, 8, 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected parsed classes.\nExpected: %+v\nGot:      %+v", want, got)
+	}
+
+	if err := MigrateDown(handle); err != nil {
+		t.Errorf("Migrating down to initial DB failed: %s", err.Error())
+	}
+}
+
+func TestDerivedValuesMigration(t *testing.T) {
+	handle := newTestDBAt(t, 11)
+	// Bobby's values match the derived ones, Alice's do not.
+	if _, err := handle.Exec(`
+		INSERT INTO character (id, name, proficiency_bonus, armor_class, initiative, speed,
+			max_hit_points, curr_hit_points, spell_slots, spell_slots_used)
+		VALUES
+			('00000000-0000-0000-0000-000000000001', 'Bobby', 3, 0, 2, 0, 0, 0, [0,0,0,0,0,0,0,0,0,0], [0,0,0,0,0,0,0,0,0,0]),
+			('00000000-0000-0000-0000-000000000002', 'Alice', 5, 0, 7, 0, 0, 0, [0,0,0,0,0,0,0,0,0,0], [0,0,0,0,0,0,0,0,0,0])`); err != nil {
+		t.Fatalf("Could not insert characters: %s", err.Error())
+	}
+	if _, err := handle.Exec(`
+		INSERT INTO character_class (character_id, class_name, level)
+		SELECT id, 'Wizard', 8 FROM character`); err != nil {
+		t.Fatalf("Could not insert classes: %s", err.Error())
+	}
+	if _, err := handle.Exec(`
+		INSERT INTO abilities (character_id, strength, dexterity, constitution, intelligence, wisdom, charisma)
+		SELECT id, 10, 14, 10, 10, 10, 10 FROM character`); err != nil {
+		t.Fatalf("Could not insert abilities: %s", err.Error())
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+
+	type values struct {
+		Name             string        `db:"name"`
+		ProficiencyBonus sql.NullInt64 `db:"proficiency_bonus"`
+		Initiative       sql.NullInt64 `db:"initiative"`
+	}
+	var got []values
+	if err := handle.Select(&got, `SELECT name, proficiency_bonus, initiative FROM character ORDER BY name`); err != nil {
+		t.Fatalf("Could not read characters: %s", err.Error())
+	}
+	want := []values{
+		{"Alice", sql.NullInt64{Int64: 5, Valid: true}, sql.NullInt64{Int64: 7, Valid: true}},
+		{"Bobby", sql.NullInt64{}, sql.NullInt64{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected values.\nExpected: %+v\nGot:      %+v", want, got)
+	}
+
+	if err := MigrateDown(handle); err != nil {
+		t.Errorf("Migrating down to initial DB failed: %s", err.Error())
+	}
+}
+
+func TestSpellSlotOverridesMigration(t *testing.T) {
+	handle := newTestDBAt(t, 13)
+	if _, err := handle.Exec(`
+		INSERT INTO character (id, name, proficiency_bonus, armor_class, initiative, speed,
+			max_hit_points, curr_hit_points, spell_slots, spell_slots_used)
+		VALUES
+			('00000000-0000-0000-0000-000000000001', 'Alice', 0, 0, 0, 0, 0, 0, [0,4,3,2,0,0,0,0,0,0], [0,0,0,0,0,0,0,0,0,0]),
+			('00000000-0000-0000-0000-000000000002', 'Bobby', 0, 0, 0, 0, 0, 0, [0,4,3,3,0,0,0,0,0,0], [0,0,0,0,0,0,0,0,0,0]),
+			('00000000-0000-0000-0000-000000000003', 'Carol', 0, 0, 0, 0, 0, 0, [0,0,0,0,0,0,0,0,0,0], [0,0,0,0,0,0,0,0,0,0]),
+			('00000000-0000-0000-0000-000000000004', 'Diane', 0, 0, 0, 0, 0, 0, [0,2,0,0,0,0,0,0,0,0], [0,0,0,0,0,0,0,0,0,0])`); err != nil {
+		t.Fatalf("Could not insert characters: %s", err.Error())
+	}
+	// Alice and Bobby are 5th level casters, Carol has no class and Diane is
+	// a 1st level artificer.
+	if _, err := handle.Exec(`
+		INSERT INTO character_class (character_id, class_name, level, spellcasting) VALUES
+			('00000000-0000-0000-0000-000000000001', 'Wizard', 3, 1),
+			('00000000-0000-0000-0000-000000000001', 'Paladin', 5, 2),
+			('00000000-0000-0000-0000-000000000002', 'Wizard', 5, 1),
+			('00000000-0000-0000-0000-000000000004', 'Artificer', 1, 2)`); err != nil {
+		t.Fatalf("Could not insert classes: %s", err.Error())
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+
+	var got []string
+	if err := handle.Select(&got, `SELECT spell_slot_overrides::VARCHAR FROM character ORDER BY name`); err != nil {
+		t.Fatalf("Could not read overrides: %s", err.Error())
+	}
+	want := []string{
+		"[NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL]",
+		"[NULL, NULL, NULL, 3, NULL, NULL, NULL, NULL, NULL, NULL]",
+		"[NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL]",
+		"[NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected overrides.\nExpected: %v\nGot:      %v", want, got)
+	}
+
+	if err := MigrateDown(handle); err != nil {
+		t.Errorf("Migrating down to initial DB failed: %s", err.Error())
+	}
+}
+
+func TestSpellcastingAbilityMigration(t *testing.T) {
+	handle := newTestDBAt(t, 15)
+	if _, err := handle.Exec(`
+		INSERT INTO character (id, name, proficiency_bonus, armor_class, initiative, speed,
+			max_hit_points, curr_hit_points, spell_slot_overrides, spell_slots_used,
+			spellcasting_ability, spell_save_dc, spell_attack_bonus)
+		VALUES
+			('00000000-0000-0000-0000-000000000001', 'Alice', NULL, 0, NULL, 0, 0, 0,
+				[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0], 'Cha', 14, 7),
+			('00000000-0000-0000-0000-000000000002', 'Bobby', NULL, 0, NULL, 0, 0, 0,
+				[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0], ' wisdom', 0, 0),
+			('00000000-0000-0000-0000-000000000003', 'Carol', NULL, 0, NULL, 0, 0, 0,
+				[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0], 'psionics', 11, 5)`); err != nil {
+		t.Fatalf("Could not insert characters: %s", err.Error())
+	}
+	if _, err := handle.Exec(`
+		INSERT INTO character_class (character_id, class_name, level)
+		SELECT id, 'Wizard', 8 FROM character`); err != nil {
+		t.Fatalf("Could not insert classes: %s", err.Error())
+	}
+	if _, err := handle.Exec(`
+		INSERT INTO abilities (character_id, strength, dexterity, constitution, intelligence, wisdom, charisma)
+		SELECT id, 10, 10, 10, 10, 10, 16 FROM character`); err != nil {
+		t.Fatalf("Could not insert abilities: %s", err.Error())
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+
+	type values struct {
+		Name                string        `db:"name"`
+		SpellcastingAbility int           `db:"spellcasting_ability"`
+		SpellSaveDC         sql.NullInt64 `db:"spell_save_dc"`
+		SpellAttackBonus    sql.NullInt64 `db:"spell_attack_bonus"`
+	}
+	var got []values
+	if err := handle.Select(&got, `
+		SELECT name, spellcasting_ability, spell_save_dc, spell_attack_bonus
+		FROM character ORDER BY name`); err != nil {
+		t.Fatalf("Could not read characters: %s", err.Error())
+	}
+	// Matching and zero values become derived, unknown abilities become none.
+	want := []values{
+		{"Alice", 6, sql.NullInt64{}, sql.NullInt64{Int64: 7, Valid: true}},
+		{"Bobby", 5, sql.NullInt64{}, sql.NullInt64{}},
+		{"Carol", 0, sql.NullInt64{}, sql.NullInt64{Int64: 5, Valid: true}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected values.\nExpected: %+v\nGot:      %+v", want, got)
+	}
+
+	if err := MigrateDown(handle); err != nil {
+		t.Errorf("Migrating down to initial DB failed: %s", err.Error())
+	}
+}
+
+func TestArmorClassMigration(t *testing.T) {
+	handle := newTestDBAt(t, 17)
+	if _, err := handle.Exec(`
+		INSERT INTO character (id, name, proficiency_bonus, armor_class, initiative, speed,
+			max_hit_points, curr_hit_points, spell_slot_overrides, spell_slots_used)
+		VALUES
+			('00000000-0000-0000-0000-000000000001', 'Alice', NULL, 18, NULL, 0, 0, 0,
+				[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0]),
+			('00000000-0000-0000-0000-000000000002', 'Bobby', NULL, 12, NULL, 0, 0, 0,
+				[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0])`); err != nil {
+		t.Fatalf("Could not insert characters: %s", err.Error())
+	}
+	if _, err := handle.Exec(`
+		INSERT INTO abilities (character_id, strength, dexterity, constitution, intelligence, wisdom, charisma)
+		SELECT id, 10, 14, 10, 10, 10, 10 FROM character`); err != nil {
+		t.Fatalf("Could not insert abilities: %s", err.Error())
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+
+	var got []sql.NullInt64
+	if err := handle.Select(&got, `SELECT armor_class FROM character ORDER BY name`); err != nil {
+		t.Fatalf("Could not read characters: %s", err.Error())
+	}
+	want := []sql.NullInt64{{Int64: 18, Valid: true}, {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected values.\nExpected: %+v\nGot:      %+v", want, got)
+	}
+
+	if err := MigrateDown(handle); err != nil {
+		t.Errorf("Migrating down to initial DB failed: %s", err.Error())
+	}
+}
+
+func TestUsedHitDiceMigration(t *testing.T) {
+	handle := newTestDBAt(t, 21)
+	if _, err := handle.Exec(`
+		INSERT INTO character (id, name, armor_class, speed, max_hit_points, curr_hit_points,
+			hit_dice, used_hit_dice, spell_slot_overrides, spell_slots_used)
+		VALUES
+			('00000000-0000-0000-0000-000000000001', 'Alice', 0, 0, 0, 0, '5d6 + 2d8', ' 6 ',
+				[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0]),
+			('00000000-0000-0000-0000-000000000002', 'Bobby', 0, 0, 0, 0, '3d10', 'two',
+				[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0])`); err != nil {
+		t.Fatalf("Could not insert characters: %s", err.Error())
+	}
+	if _, err := handle.Exec(`
+		INSERT INTO character_class (character_id, class_name, level, hit_die)
+		VALUES
+			('00000000-0000-0000-0000-000000000001', 'Wizard', 5, 6),
+			('00000000-0000-0000-0000-000000000001', 'Cleric', 2, 8),
+			('00000000-0000-0000-0000-000000000002', 'Fighter', 3, 10)`); err != nil {
+		t.Fatalf("Could not insert classes: %s", err.Error())
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+
+	var got []int
+	if err := handle.Select(&got, `SELECT hit_dice_used FROM character_class ORDER BY class_name`); err != nil {
+		t.Fatalf("Could not read classes: %s", err.Error())
+	}
+	// Cleric, Fighter, Wizard. Unparseable text counts as no dice used.
+	want := []int{1, 0, 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected values.\nExpected: %+v\nGot:      %+v", want, got)
+	}
+
+	if err := MigrateDown(handle); err != nil {
+		t.Errorf("Migrating down to initial DB failed: %s", err.Error())
+	}
+}
+
+func TestConditionMigration(t *testing.T) {
+	handle := newTestDBAt(t, 25)
+	if _, err := handle.Exec(`
+		INSERT INTO character (id, name, armor_class, speed, max_hit_points, curr_hit_points,
+			condition, spell_slot_overrides, spell_slots_used)
+		VALUES ('00000000-0000-0000-0000-000000000001', 'Alice', 0, 0, 0, 0, 'poisoned, Prone / Hexed',
+			[NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL], [0,0,0,0,0,0,0,0,0,0])`); err != nil {
+		t.Fatalf("Could not insert character: %s", err.Error())
+	}
+	if err := MigrateUp(handle); err != nil {
+		t.Fatalf("Migration to current version failed: %s", err.Error())
+	}
+
+	type condition struct {
+		Condition int    `db:"condition"`
+		Name      string `db:"name"`
+	}
+	var got []condition
+	if err := handle.Select(&got, `SELECT condition, name FROM character_condition ORDER BY condition`); err != nil {
+		t.Fatalf("Could not read conditions: %s", err.Error())
+	}
+	// Unknown conditions are kept as custom ones.
+	want := []condition{{0, "Hexed"}, {10, ""}, {11, ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected values.\nExpected: %+v\nGot:      %+v", want, got)
+	}
+
+	if err := MigrateDown(handle); err != nil {
+		t.Errorf("Migrating down to initial DB failed: %s", err.Error())
+	}
+}
@@ -0,0 +1,19 @@
+-- +duckUp
+
+-- Append-only log of gameplay actions. Events recorded while a character is
+-- loaded share a session_id, loading it again starts a new session.
+CREATE TABLE IF NOT EXISTS session_event (
+    id UUID PRIMARY KEY DEFAULT uuid(),
+    character_id UUID NOT NULL,
+    session_id UUID NOT NULL,
+    actor TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL DEFAULT '',
+    args TEXT NOT NULL DEFAULT '',
+    state_before TEXT NOT NULL DEFAULT '',
+    state_after TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+);
+
+-- +duckDown
+
+DROP TABLE IF EXISTS session_event;
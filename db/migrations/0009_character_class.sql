@@ -0,0 +1,71 @@
+-- +duckUp
+
+CREATE TABLE IF NOT EXISTS character_class (
+    id UUID PRIMARY KEY DEFAULT uuid(),
+    character_id UUID NOT NULL,
+    class_name TEXT NOT NULL DEFAULT '',
+    subclass TEXT NOT NULL DEFAULT '',
+    level INTEGER NOT NULL DEFAULT 1,
+    hit_die INTEGER NOT NULL DEFAULT 8,
+    spellcasting INTEGER NOT NULL DEFAULT 0 CHECK (spellcasting BETWEEN 0 AND 4),
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+);
+
+-- Best-effort parse of the free-text class_levels, e.g. "Wizard 5 / Cleric (Life) 2".
+-- Parts are split on / , and +. The first number is the level (default 1), a
+-- parenthesized part the subclass, and a "Level" prefix is dropped.
+-- Unknown classes get a d8 and no spellcasting.
+-- spellcasting: 0 none, 1 full, 2 half, 3 third, 4 pact magic
+INSERT INTO character_class (character_id, class_name, subclass, level, hit_die, spellcasting)
+SELECT
+    character_id,
+    name,
+    subclass,
+    level,
+    CASE lower(name)
+        WHEN 'barbarian' THEN 12
+        WHEN 'fighter' THEN 10
+        WHEN 'paladin' THEN 10
+        WHEN 'ranger' THEN 10
+        WHEN 'sorcerer' THEN 6
+        WHEN 'wizard' THEN 6
+        ELSE 8
+    END,
+    CASE lower(name)
+        WHEN 'bard' THEN 1
+        WHEN 'cleric' THEN 1
+        WHEN 'druid' THEN 1
+        WHEN 'sorcerer' THEN 1
+        WHEN 'wizard' THEN 1
+        WHEN 'artificer' THEN 2
+        WHEN 'paladin' THEN 2
+        WHEN 'ranger' THEN 2
+        WHEN 'warlock' THEN 4
+        ELSE 0
+    END
+FROM (
+    SELECT
+        character_id,
+        trim(regexp_replace(regexp_replace(regexp_replace(part,
+            '\([^)]*\)', '', 'g'),
+            '(?i)\b(level|lvl)\b|\d+', '', 'g'),
+            '\s+', ' ', 'g')) AS name,
+        trim(regexp_extract(part, '\(([^)]*)\)', 1)) AS subclass,
+        greatest(1, coalesce(try_cast(regexp_extract(part, '\d+') AS INTEGER), 1)) AS level
+    FROM (
+        SELECT id AS character_id, trim(unnest(regexp_split_to_array(class_levels, '[/,+]'))) AS part
+        FROM character
+    )
+    WHERE part <> ''
+);
+
+-- +duckDown
+
+UPDATE character SET class_levels = coalesce((
+    SELECT string_agg(cc.class_name || ' ' || cc.level, ' / ' ORDER BY cc.level DESC, cc.class_name)
+    FROM character_class cc
+    WHERE cc.character_id = character.id
+), '');
+
+DROP TABLE character_class;
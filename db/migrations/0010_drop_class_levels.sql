@@ -0,0 +1,8 @@
+-- +duckUp
+
+-- Split from 0009 since DuckDB doesn't support mixing dropping columns and modifying data in the same transaction.
+ALTER TABLE character DROP class_levels;
+
+-- +duckDown
+
+ALTER TABLE character ADD COLUMN class_levels TEXT DEFAULT '';
@@ -0,0 +1,18 @@
+-- +duckUp
+
+-- NULL proficiency_bonus, initiative and passive_* mean derived.
+ALTER TABLE character ALTER COLUMN proficiency_bonus DROP NOT NULL;
+ALTER TABLE character ALTER COLUMN initiative DROP NOT NULL;
+ALTER TABLE character ADD COLUMN initiative_bonus INTEGER DEFAULT 0;
+ALTER TABLE character ADD COLUMN passive_perception INTEGER;
+ALTER TABLE character ADD COLUMN passive_insight INTEGER;
+ALTER TABLE character ADD COLUMN passive_investigation INTEGER;
+
+-- +duckDown
+
+ALTER TABLE character DROP passive_investigation;
+ALTER TABLE character DROP passive_insight;
+ALTER TABLE character DROP passive_perception;
+ALTER TABLE character DROP initiative_bonus;
+ALTER TABLE character ALTER COLUMN initiative SET NOT NULL;
+ALTER TABLE character ALTER COLUMN proficiency_bonus SET NOT NULL;
@@ -0,0 +1,25 @@
+-- +duckUp
+
+-- Values that match what would be derived become derived, others are kept
+-- as overrides.
+UPDATE character SET proficiency_bonus = NULL
+WHERE proficiency_bonus = 2 + (greatest(coalesce((
+    SELECT sum(level) FROM character_class WHERE character_id = character.id
+), 0), 1) - 1) // 4;
+
+UPDATE character SET initiative = NULL
+WHERE initiative = coalesce((
+    SELECT floor((dexterity - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0);
+
+-- +duckDown
+
+UPDATE character SET proficiency_bonus = 2 + (greatest(coalesce((
+    SELECT sum(level) FROM character_class WHERE character_id = character.id
+), 0), 1) - 1) // 4
+WHERE proficiency_bonus IS NULL;
+
+UPDATE character SET initiative = coalesce((
+    SELECT floor((dexterity - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0) + coalesce(initiative_bonus, 0)
+WHERE initiative IS NULL;
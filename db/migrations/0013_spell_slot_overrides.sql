@@ -0,0 +1,16 @@
+-- +duckUp
+
+-- Spell slot maximums are derived from the classes. spell_slot_overrides
+-- holds manual maximums per spell level, NULL elements are derived.
+ALTER TABLE character RENAME COLUMN spell_slots TO spell_slot_overrides;
+-- Pact magic slots, NULL means derived from the pact magic class levels.
+ALTER TABLE character ADD COLUMN pact_slots INTEGER;
+ALTER TABLE character ADD COLUMN pact_slot_level INTEGER;
+ALTER TABLE character ADD COLUMN pact_slots_used INTEGER DEFAULT 0;
+
+-- +duckDown
+
+ALTER TABLE character DROP pact_slots_used;
+ALTER TABLE character DROP pact_slot_level;
+ALTER TABLE character DROP pact_slots;
+ALTER TABLE character RENAME COLUMN spell_slot_overrides TO spell_slots;
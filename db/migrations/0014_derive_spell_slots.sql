@@ -0,0 +1,74 @@
+-- +duckUp
+
+-- Slot maximums that match the ones derived from the classes become derived,
+-- others are kept as overrides. Mirrors models.SpellSlotsForCasterLevel and
+-- models.CasterLevel.
+UPDATE character SET spell_slot_overrides = list_transform(
+    list_zip(character.spell_slot_overrides::INTEGER[], d.slots),
+    p -> CASE WHEN p[1] = p[2] THEN NULL ELSE p[1] END)
+FROM (
+    SELECT c.id, list_transform(range(10), s -> CASE
+        WHEN s = 0 OR lvl < 2 * s - 1 THEN 0
+        ELSE least(lvl - 2 * s + 2 + CASE WHEN s <= 3 THEN 1 ELSE 0 END,
+                CASE WHEN s = 1 THEN 4 WHEN s <= 4 THEN 3 WHEN s = 5 THEN 2 ELSE 1 END)
+            + CASE WHEN s BETWEEN 5 AND 7 AND lvl >= 13 + s THEN 1 ELSE 0 END
+        END) AS slots
+    FROM (
+        SELECT c.id, least(coalesce(cl.lvl, 0), 20) AS lvl
+        FROM character c LEFT JOIN (
+            SELECT character_id, CASE
+                WHEN count(*) = 1 AND sum(level) FILTER (WHERE spellcasting = 2 AND NOT artificer) >= 2
+                    THEN (sum(level) + 1) // 2
+                WHEN count(*) = 1 AND sum(level) FILTER (WHERE spellcasting = 3) >= 3
+                    THEN (sum(level) + 2) // 3
+                ELSE coalesce(sum(level) FILTER (WHERE spellcasting = 1), 0)
+                    + (coalesce(sum(level) FILTER (WHERE spellcasting = 2 AND artificer), 0) + 1) // 2
+                    + coalesce(sum(level) FILTER (WHERE spellcasting = 2 AND NOT artificer), 0) // 2
+                    + coalesce(sum(level) FILTER (WHERE spellcasting = 3), 0) // 3
+                END AS lvl
+            FROM (
+                SELECT *, lower(trim(class_name)) = 'artificer' AS artificer
+                FROM character_class
+            )
+            WHERE spellcasting IN (1, 2, 3)
+            GROUP BY character_id
+        ) cl ON cl.character_id = c.id
+    ) c
+) d
+WHERE d.id = character.id;
+
+-- +duckDown
+
+UPDATE character SET spell_slot_overrides = list_transform(
+    list_zip(character.spell_slot_overrides::INTEGER[], d.slots),
+    p -> coalesce(p[1], p[2]))
+FROM (
+    SELECT c.id, list_transform(range(10), s -> CASE
+        WHEN s = 0 OR lvl < 2 * s - 1 THEN 0
+        ELSE least(lvl - 2 * s + 2 + CASE WHEN s <= 3 THEN 1 ELSE 0 END,
+                CASE WHEN s = 1 THEN 4 WHEN s <= 4 THEN 3 WHEN s = 5 THEN 2 ELSE 1 END)
+            + CASE WHEN s BETWEEN 5 AND 7 AND lvl >= 13 + s THEN 1 ELSE 0 END
+        END) AS slots
+    FROM (
+        SELECT c.id, least(coalesce(cl.lvl, 0), 20) AS lvl
+        FROM character c LEFT JOIN (
+            SELECT character_id, CASE
+                WHEN count(*) = 1 AND sum(level) FILTER (WHERE spellcasting = 2 AND NOT artificer) >= 2
+                    THEN (sum(level) + 1) // 2
+                WHEN count(*) = 1 AND sum(level) FILTER (WHERE spellcasting = 3) >= 3
+                    THEN (sum(level) + 2) // 3
+                ELSE coalesce(sum(level) FILTER (WHERE spellcasting = 1), 0)
+                    + (coalesce(sum(level) FILTER (WHERE spellcasting = 2 AND artificer), 0) + 1) // 2
+                    + coalesce(sum(level) FILTER (WHERE spellcasting = 2 AND NOT artificer), 0) // 2
+                    + coalesce(sum(level) FILTER (WHERE spellcasting = 3), 0) // 3
+                END AS lvl
+            FROM (
+                SELECT *, lower(trim(class_name)) = 'artificer' AS artificer
+                FROM character_class
+            )
+            WHERE spellcasting IN (1, 2, 3)
+            GROUP BY character_id
+        ) cl ON cl.character_id = c.id
+    ) c
+) d
+WHERE d.id = character.id;
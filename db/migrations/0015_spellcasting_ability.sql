@@ -0,0 +1,43 @@
+-- +duckUp
+
+-- spellcasting_ability: 0 none, 1 Str, 2 Dex, 3 Con, 4 Int, 5 Wis, 6 Cha.
+-- Free text that does not start with an ability abbreviation becomes none.
+ALTER TABLE character ALTER spellcasting_ability SET DATA TYPE INTEGER USING
+    CASE lower(left(trim(spellcasting_ability), 3))
+        WHEN 'str' THEN 1
+        WHEN 'dex' THEN 2
+        WHEN 'con' THEN 3
+        WHEN 'int' THEN 4
+        WHEN 'wis' THEN 5
+        WHEN 'cha' THEN 6
+        ELSE 0
+    END;
+ALTER TABLE character ALTER COLUMN spellcasting_ability SET DEFAULT 0;
+
+-- NULL spell_save_dc and spell_attack_bonus mean derived.
+ALTER TABLE character ALTER COLUMN spell_save_dc DROP NOT NULL;
+ALTER TABLE character ALTER COLUMN spell_attack_bonus DROP NOT NULL;
+ALTER TABLE character ADD COLUMN spell_save_dc_item_bonus INTEGER DEFAULT 0;
+ALTER TABLE character ADD COLUMN spell_attack_item_bonus INTEGER DEFAULT 0;
+
+-- 0 uses the spellcasting ability of the character.
+ALTER TABLE character_class ADD COLUMN spellcasting_ability INTEGER DEFAULT 0;
+
+-- +duckDown
+
+ALTER TABLE character_class DROP spellcasting_ability;
+ALTER TABLE character DROP spell_attack_item_bonus;
+ALTER TABLE character DROP spell_save_dc_item_bonus;
+ALTER TABLE character ALTER COLUMN spell_attack_bonus SET NOT NULL;
+ALTER TABLE character ALTER COLUMN spell_save_dc SET NOT NULL;
+ALTER TABLE character ALTER spellcasting_ability SET DATA TYPE TEXT USING
+    CASE spellcasting_ability
+        WHEN 1 THEN 'Str'
+        WHEN 2 THEN 'Dex'
+        WHEN 3 THEN 'Con'
+        WHEN 4 THEN 'Int'
+        WHEN 5 THEN 'Wis'
+        WHEN 6 THEN 'Cha'
+        ELSE ''
+    END;
+ALTER TABLE character ALTER COLUMN spellcasting_ability SET DEFAULT '';
@@ -0,0 +1,61 @@
+-- +duckUp
+
+-- Values that match what would be derived become derived, others are kept
+-- as overrides. 0 was the default for both and is treated as never set.
+UPDATE character SET spell_save_dc = NULL
+WHERE spell_save_dc = 0 OR spell_save_dc = 8 + coalesce(proficiency_bonus, 2 + (greatest(coalesce((
+    SELECT sum(level) FROM character_class WHERE character_id = character.id
+), 0), 1) - 1) // 4) + coalesce((
+    SELECT floor((CASE character.spellcasting_ability
+        WHEN 1 THEN strength
+        WHEN 2 THEN dexterity
+        WHEN 3 THEN constitution
+        WHEN 4 THEN intelligence
+        WHEN 5 THEN wisdom
+        WHEN 6 THEN charisma
+    END - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0);
+
+UPDATE character SET spell_attack_bonus = NULL
+WHERE spell_attack_bonus = 0 OR spell_attack_bonus = coalesce(proficiency_bonus, 2 + (greatest(coalesce((
+    SELECT sum(level) FROM character_class WHERE character_id = character.id
+), 0), 1) - 1) // 4) + coalesce((
+    SELECT floor((CASE character.spellcasting_ability
+        WHEN 1 THEN strength
+        WHEN 2 THEN dexterity
+        WHEN 3 THEN constitution
+        WHEN 4 THEN intelligence
+        WHEN 5 THEN wisdom
+        WHEN 6 THEN charisma
+    END - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0);
+
+-- +duckDown
+
+UPDATE character SET spell_save_dc = 8 + coalesce(spell_save_dc_item_bonus, 0) + coalesce(proficiency_bonus, 2 + (greatest(coalesce((
+    SELECT sum(level) FROM character_class WHERE character_id = character.id
+), 0), 1) - 1) // 4) + coalesce((
+    SELECT floor((CASE character.spellcasting_ability
+        WHEN 1 THEN strength
+        WHEN 2 THEN dexterity
+        WHEN 3 THEN constitution
+        WHEN 4 THEN intelligence
+        WHEN 5 THEN wisdom
+        WHEN 6 THEN charisma
+    END - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0)
+WHERE spell_save_dc IS NULL;
+
+UPDATE character SET spell_attack_bonus = coalesce(spell_attack_item_bonus, 0) + coalesce(proficiency_bonus, 2 + (greatest(coalesce((
+    SELECT sum(level) FROM character_class WHERE character_id = character.id
+), 0), 1) - 1) // 4) + coalesce((
+    SELECT floor((CASE character.spellcasting_ability
+        WHEN 1 THEN strength
+        WHEN 2 THEN dexterity
+        WHEN 3 THEN constitution
+        WHEN 4 THEN intelligence
+        WHEN 5 THEN wisdom
+        WHEN 6 THEN charisma
+    END - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0)
+WHERE spell_attack_bonus IS NULL;
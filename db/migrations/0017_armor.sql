@@ -0,0 +1,22 @@
+-- +duckUp
+
+-- armor_base_ac 0 means the item is not armor, a NULL armor_dex_cap means
+-- the full Dexterity modifier applies.
+ALTER TABLE item ADD COLUMN armor_base_ac INTEGER DEFAULT 0;
+ALTER TABLE item ADD COLUMN armor_dex_cap INTEGER;
+ALTER TABLE item ADD COLUMN shield_bonus INTEGER DEFAULT 0;
+ALTER TABLE item ADD COLUMN magic_ac_bonus INTEGER DEFAULT 0;
+
+-- NULL armor_class means derived.
+-- unarmored_defense: 0 10 + Dex, 1 + Con, 2 + Wis, 3 13 + Dex
+ALTER TABLE character ALTER COLUMN armor_class DROP NOT NULL;
+ALTER TABLE character ADD COLUMN unarmored_defense INTEGER DEFAULT 0;
+
+-- +duckDown
+
+ALTER TABLE character DROP unarmored_defense;
+ALTER TABLE character ALTER COLUMN armor_class SET NOT NULL;
+ALTER TABLE item DROP magic_ac_bonus;
+ALTER TABLE item DROP shield_bonus;
+ALTER TABLE item DROP armor_dex_cap;
+ALTER TABLE item DROP armor_base_ac;
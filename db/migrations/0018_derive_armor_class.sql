@@ -0,0 +1,15 @@
+-- +duckUp
+
+-- No item has armor properties yet, so only the unarmored value can match.
+UPDATE character SET armor_class = NULL
+WHERE armor_class = 10 + coalesce((
+    SELECT floor((dexterity - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0);
+
+-- +duckDown
+
+-- Armor is not reconstructed, derived values fall back to the unarmored AC.
+UPDATE character SET armor_class = 10 + coalesce((
+    SELECT floor((dexterity - 10) / 2) FROM abilities WHERE character_id = character.id
+), 0)
+WHERE armor_class IS NULL;
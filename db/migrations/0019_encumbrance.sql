@@ -0,0 +1,12 @@
+-- +duckUp
+
+-- weight is in pounds per unit.
+ALTER TABLE item ADD COLUMN weight DOUBLE DEFAULT 0;
+ALTER TABLE character ADD COLUMN coin_weight INTEGER DEFAULT 0;
+ALTER TABLE character ADD COLUMN variant_encumbrance INTEGER DEFAULT 0;
+
+-- +duckDown
+
+ALTER TABLE character DROP variant_encumbrance;
+ALTER TABLE character DROP coin_weight;
+ALTER TABLE item DROP weight;
@@ -0,0 +1,9 @@
+-- +duckUp
+
+ALTER TABLE item ADD COLUMN attuned INTEGER DEFAULT 0;
+ALTER TABLE character ADD COLUMN attunement_limit INTEGER DEFAULT 3;
+
+-- +duckDown
+
+ALTER TABLE character DROP attunement_limit;
+ALTER TABLE item DROP attuned;
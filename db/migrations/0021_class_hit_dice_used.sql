@@ -0,0 +1,7 @@
+-- +duckUp
+
+ALTER TABLE character_class ADD COLUMN hit_dice_used INTEGER DEFAULT 0;
+
+-- +duckDown
+
+ALTER TABLE character_class DROP hit_dice_used;
@@ -0,0 +1,34 @@
+-- +duckUp
+
+-- Best-effort parse of the free-text used_hit_dice as a number of dice. They
+-- are assigned to the classes by descending level, up to each
+-- class level. The hit_dice text is replaced by the class levels.
+UPDATE character_class SET hit_dice_used = d.used
+FROM (
+    SELECT
+        cc.id,
+        greatest(0, least(
+            cc.level,
+            coalesce(try_cast(trim(c.used_hit_dice) AS INTEGER), 0) - coalesce(sum(cc.level) OVER (
+                PARTITION BY cc.character_id
+                ORDER BY cc.level DESC, cc.class_name ASC
+                ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+            ), 0)
+        )) AS used
+    FROM character_class cc JOIN character c ON c.id = cc.character_id
+) d
+WHERE character_class.id = d.id;
+
+-- +duckDown
+
+UPDATE character SET
+    hit_dice = coalesce((
+        SELECT string_agg(concat(n, 'd', hit_die), ' + ' ORDER BY hit_die DESC)
+        FROM (
+            SELECT hit_die, sum(level) AS n FROM character_class
+            WHERE character_id = character.id GROUP BY hit_die
+        )
+    ), ''),
+    used_hit_dice = coalesce((
+        SELECT sum(hit_dice_used) FROM character_class WHERE character_id = character.id
+    ), 0)::VARCHAR;
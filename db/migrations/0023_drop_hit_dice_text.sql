@@ -0,0 +1,10 @@
+-- +duckUp
+
+-- Split from 0022 since DuckDB doesn't support mixing dropping columns and modifying data in the same transaction.
+ALTER TABLE character DROP used_hit_dice;
+ALTER TABLE character DROP hit_dice;
+
+-- +duckDown
+
+ALTER TABLE character ADD COLUMN hit_dice TEXT DEFAULT '';
+ALTER TABLE character ADD COLUMN used_hit_dice TEXT DEFAULT '';
@@ -0,0 +1,21 @@
+-- +duckUp
+
+-- max_formula: 0 fixed, 1 level, 2 ability modifier, 3 proficiency bonus
+-- recharge: 0 short rest, 1 long rest, 2 dawn
+CREATE TABLE IF NOT EXISTS resource (
+    id UUID PRIMARY KEY DEFAULT uuid(),
+    character_id UUID NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    maximum INTEGER NOT NULL DEFAULT 0,
+    max_formula INTEGER NOT NULL DEFAULT 0 CHECK (max_formula BETWEEN 0 AND 3),
+    max_class TEXT NOT NULL DEFAULT '',
+    max_ability INTEGER NOT NULL DEFAULT 0 CHECK (max_ability BETWEEN 0 AND 6),
+    used INTEGER NOT NULL DEFAULT 0,
+    recharge INTEGER NOT NULL DEFAULT 0 CHECK (recharge BETWEEN 0 AND 2),
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+);
+
+-- +duckDown
+
+DROP TABLE resource;
@@ -0,0 +1,45 @@
+-- +duckUp
+
+-- condition: 0 custom (named by name), 1 blinded, 2 charmed, 3 deafened,
+-- 4 frightened, 5 grappled, 6 incapacitated, 7 invisible, 8 paralyzed,
+-- 9 petrified, 10 poisoned, 11 prone, 12 restrained, 13 stunned, 14 unconscious
+CREATE TABLE IF NOT EXISTS character_condition (
+    id UUID PRIMARY KEY DEFAULT uuid(),
+    character_id UUID NOT NULL,
+    condition INTEGER NOT NULL DEFAULT 0 CHECK (condition BETWEEN 0 AND 14),
+    name TEXT NOT NULL DEFAULT '',
+    duration TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+);
+
+-- Best-effort parse of the free-text condition, split on , / and +. Known
+-- conditions are matched ignoring case, anything else becomes a custom one.
+INSERT INTO character_condition (character_id, condition, name)
+SELECT
+    character_id,
+    coalesce(list_position(
+        ['blinded', 'charmed', 'deafened', 'frightened', 'grappled', 'incapacitated', 'invisible',
+         'paralyzed', 'petrified', 'poisoned', 'prone', 'restrained', 'stunned', 'unconscious'],
+        lower(part)), 0) AS condition,
+    part
+FROM (
+    SELECT id AS character_id, trim(unnest(regexp_split_to_array(coalesce(condition, ''), '[,/+]'))) AS part
+    FROM character
+)
+WHERE part <> '';
+
+UPDATE character_condition SET name = '' WHERE condition <> 0;
+
+-- +duckDown
+
+UPDATE character SET condition = coalesce((
+    SELECT string_agg(CASE WHEN cc.condition = 0 THEN cc.name ELSE
+        (['Blinded', 'Charmed', 'Deafened', 'Frightened', 'Grappled', 'Incapacitated', 'Invisible',
+          'Paralyzed', 'Petrified', 'Poisoned', 'Prone', 'Restrained', 'Stunned', 'Unconscious'])[cc.condition]
+        END, ', ' ORDER BY cc.created_at)
+    FROM character_condition cc
+    WHERE cc.character_id = character.id
+), '');
+
+DROP TABLE character_condition;
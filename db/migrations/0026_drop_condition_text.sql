@@ -0,0 +1,7 @@
+-- +duckUp
+
+ALTER TABLE character DROP COLUMN condition;
+
+-- +duckDown
+
+ALTER TABLE character ADD COLUMN condition TEXT DEFAULT '';
@@ -0,0 +1,8 @@
+-- +duckUp
+
+-- exhaustion_rules: 0 tiered (2014), 1 penalty per level (2024)
+ALTER TABLE character ADD COLUMN exhaustion_rules INTEGER DEFAULT 0;
+
+-- +duckDown
+
+ALTER TABLE character DROP exhaustion_rules;
@@ -0,0 +1,7 @@
+-- +duckUp
+
+ALTER TABLE character ADD COLUMN concentration_spell TEXT DEFAULT '';
+
+-- +duckDown
+
+ALTER TABLE character DROP concentration_spell;
@@ -0,0 +1,15 @@
+-- +duckUp
+
+-- Append-only log of dice rolled with the roll quick action.
+CREATE TABLE IF NOT EXISTS dice_roll (
+    id UUID PRIMARY KEY DEFAULT uuid(),
+    character_id UUID NOT NULL,
+    expression TEXT NOT NULL DEFAULT '',
+    total INTEGER NOT NULL DEFAULT 0,
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+);
+
+-- +duckDown
+
+DROP TABLE IF EXISTS dice_roll;
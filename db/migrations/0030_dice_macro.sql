@@ -0,0 +1,15 @@
+-- +duckUp
+
+-- Named dice expressions, rolled with e.g. roll smite.
+CREATE TABLE IF NOT EXISTS dice_macro (
+    id UUID PRIMARY KEY DEFAULT uuid(),
+    character_id UUID NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    expression TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+);
+
+-- +duckDown
+
+DROP TABLE IF EXISTS dice_macro;
@@ -0,0 +1,659 @@
+// Package dice rolls dice expressions in the syntax of dicestats, e.g.
+// "4d6kh3", "adv(1d20) + 5" or "[1d20 + 7 >= 15] * (2d6 + 4)", and
+// derives statistics from dicestats.
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Limits keeping a single roll cheap.
+const (
+	maxDice    = 1000
+	maxRepeats = 100
+)
+
+// Roller rolls a die with the given number of sides.
+type Roller func(sides int) int
+
+// Random rolls with the global random source.
+func Random(sides int) int {
+	return rand.IntN(sides) + 1
+}
+
+// Seeded returns a Roller whose rolls are reproducible for the same seed.
+func Seeded(seed uint64) Roller {
+	r := rand.New(rand.NewPCG(seed, seed))
+	return func(sides int) int { return r.IntN(sides) + 1 }
+}
+
+// Group is one set of dice rolled together, e.g. 4d6kh3.
+type Group struct {
+	Notation string
+	Rolls    []int
+	// Dropped marks the rolls not counted, e.g. the lowest die of 4d6kh3.
+	Dropped []bool
+}
+
+// String lists the rolls of the group, dropped ones in parentheses.
+func (g Group) String() string {
+	rolls := make([]string, len(g.Rolls))
+	for i, r := range g.Rolls {
+		rolls[i] = strconv.Itoa(r)
+		if g.Dropped[i] {
+			rolls[i] = "(" + rolls[i] + ")"
+		}
+	}
+	return g.Notation + ": " + strings.Join(rolls, " ")
+}
+
+// Result is the outcome of rolling an expression.
+type Result struct {
+	Total int
+	// Dice are the groups in the order they were rolled.
+	Dice []Group
+}
+
+// Detail lists all rolled dice, e.g. "4d6kh3: 6 5 4 (1), 1d20: 3".
+func (r Result) Detail() string {
+	groups := make([]string, len(r.Dice))
+	for i, g := range r.Dice {
+		groups[i] = g.String()
+	}
+	return strings.Join(groups, ", ")
+}
+
+// Roll parses and rolls the expression.
+func Roll(expr string, roll Roller) (Result, error) {
+	e, err := Parse(expr)
+	if err != nil {
+		return Result{}, err
+	}
+	return e.Roll(roll)
+}
+
+// Expr is a parsed dice expression, it can be rolled any number of times.
+type Expr struct {
+	root node
+}
+
+func (e Expr) Roll(roll Roller) (Result, error) {
+	return e.roll(&rollContext{roll: roll})
+}
+
+// RollCritical rolls the expression with every group of dice rolled twice,
+// like the damage of a critical hit. Dice compared in [ ] are rolled once,
+// they decide whether damage is dealt.
+func (e Expr) RollCritical(roll Roller) (Result, error) {
+	return e.roll(&rollContext{roll: roll, critical: true})
+}
+
+func (e Expr) String() string {
+	return format(e.root, false, 0)
+}
+
+// CriticalString is the expression RollCritical rolls, e.g. "2(1d8) + 3" for
+// "1d8 + 3", to query its statistics.
+func (e Expr) CriticalString() string {
+	return format(e.root, true, 0)
+}
+
+func (e Expr) roll(ctx *rollContext) (Result, error) {
+	total, err := e.root.eval(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Total: total, Dice: ctx.groups}, nil
+}
+
+type rollContext struct {
+	roll     Roller
+	critical bool
+	groups   []Group
+}
+
+type node interface {
+	eval(ctx *rollContext) (int, error)
+}
+
+type number int
+
+func (n number) eval(*rollContext) (int, error) {
+	return int(n), nil
+}
+
+// keep selects the dice counted by a dice node.
+type keep int
+
+const (
+	keepAll keep = iota
+	keepHighest
+	keepLowest
+	dropHighest
+	dropLowest
+)
+
+var keepSuffixes = map[string]keep{"kh": keepHighest, "kl": keepLowest, "dh": dropHighest, "dl": dropLowest}
+
+type diceNode struct {
+	count, sides int
+	keep         keep
+	n            int
+	notation     string
+}
+
+func (d diceNode) eval(ctx *rollContext) (int, error) {
+	total := d.rollOnce(ctx)
+	if ctx.critical {
+		total += d.rollOnce(ctx)
+	}
+	return total, nil
+}
+
+func (d diceNode) rollOnce(ctx *rollContext) int {
+	rolls := make([]int, d.count)
+	for i := range rolls {
+		rolls[i] = ctx.roll(d.sides)
+	}
+	// Order the dice from highest to lowest to find the dropped ones, ties
+	// keep the order they were rolled in.
+	order := make([]int, d.count)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return rolls[b] - rolls[a] })
+	var drop []int
+	switch d.keep {
+	case keepHighest:
+		drop = order[min(d.n, d.count):]
+	case keepLowest:
+		drop = order[:max(0, d.count-d.n)]
+	case dropHighest:
+		drop = order[:min(d.n, d.count)]
+	case dropLowest:
+		drop = order[max(0, d.count-d.n):]
+	}
+	dropped := make([]bool, d.count)
+	for _, i := range drop {
+		dropped[i] = true
+	}
+	total := 0
+	for i, r := range rolls {
+		if !dropped[i] {
+			total += r
+		}
+	}
+	ctx.groups = append(ctx.groups, Group{Notation: d.notation, Rolls: rolls, Dropped: dropped})
+	return total
+}
+
+type binary struct {
+	op          byte
+	left, right node
+}
+
+func (b binary) eval(ctx *rollContext) (int, error) {
+	l, err := b.left.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r, err := b.right.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	switch b.op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	}
+	if r == 0 {
+		return 0, errors.New("division by zero")
+	}
+	// Round down like the rules do.
+	q := l / r
+	if (l%r != 0) && ((l < 0) != (r < 0)) {
+		q--
+	}
+	return q, nil
+}
+
+type negate struct {
+	x node
+}
+
+func (n negate) eval(ctx *rollContext) (int, error) {
+	v, err := n.x.eval(ctx)
+	return -v, err
+}
+
+// comparison is 1 if it holds and 0 otherwise, e.g. [1d20 + 5 >= 15].
+type comparison struct {
+	op          string
+	left, right node
+}
+
+func (c comparison) eval(ctx *rollContext) (int, error) {
+	critical := ctx.critical
+	ctx.critical = false
+	defer func() { ctx.critical = critical }()
+	l, err := c.left.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r, err := c.right.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var holds bool
+	switch c.op {
+	case ">=":
+		holds = l >= r
+	case "<=":
+		holds = l <= r
+	case ">":
+		holds = l > r
+	case "<":
+		holds = l < r
+	case "==", "=":
+		holds = l == r
+	case "!=":
+		holds = l != r
+	}
+	if holds {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// draws combines n independent draws of an expression, e.g. the sum for
+// 3(1d6 + 1) or the highest for best(2, 1d20).
+type draws struct {
+	// name is the function drawing, empty for a sum.
+	name    string
+	n       int
+	x       node
+	combine func(values []int) int
+}
+
+func (d draws) eval(ctx *rollContext) (int, error) {
+	values := make([]int, d.n)
+	for i := range values {
+		v, err := d.x.eval(ctx)
+		if err != nil {
+			return 0, err
+		}
+		values[i] = v
+	}
+	return d.combine(values), nil
+}
+
+// extremum is max or min of its arguments, each evaluated once.
+type extremum struct {
+	name    string
+	args    []node
+	combine func(values []int) int
+}
+
+func (e extremum) eval(ctx *rollContext) (int, error) {
+	values := make([]int, len(e.args))
+	for i, a := range e.args {
+		v, err := a.eval(ctx)
+		if err != nil {
+			return 0, err
+		}
+		values[i] = v
+	}
+	return e.combine(values), nil
+}
+
+// Precedences of the nodes, a node is parenthesized where a higher one is
+// expected.
+const (
+	comparisonPrecedence = iota
+	sumPrecedence
+	productPrecedence
+	unaryPrecedence
+)
+
+// format writes the node back in the syntax Parse reads, rolling the dice
+// twice if critical.
+func format(n node, critical bool, precedence int) string {
+	s, own := "", unaryPrecedence+1
+	switch n := n.(type) {
+	case number:
+		s = strconv.Itoa(int(n))
+	case diceNode:
+		s = n.notation
+		if critical {
+			s = "2(" + s + ")"
+		}
+	case binary:
+		own = sumPrecedence
+		if n.op == '*' || n.op == '/' {
+			own = productPrecedence
+		}
+		s = format(n.left, critical, own) + " " + string(n.op) + " " + format(n.right, critical, own+1)
+	case negate:
+		own = unaryPrecedence
+		s = "-" + format(n.x, critical, own)
+	case comparison:
+		s = "[" + format(n.left, false, sumPrecedence) + " " + n.op + " " + format(n.right, false, sumPrecedence) + "]"
+	case draws:
+		switch n.name {
+		case "":
+			s = fmt.Sprintf("%d(%s)", n.n, format(n.x, critical, 0))
+		case "adv", "dis":
+			s = fmt.Sprintf("%s(%s)", n.name, format(n.x, critical, 0))
+		default:
+			s = fmt.Sprintf("%s(%d, %s)", n.name, n.n, format(n.x, critical, 0))
+		}
+	case extremum:
+		args := make([]string, len(n.args))
+		for i, a := range n.args {
+			args[i] = format(a, critical, 0)
+		}
+		s = n.name + "(" + strings.Join(args, ", ") + ")"
+	}
+	if own < precedence {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Parse parses a dice expression. A comparison at the top level rolls 1 if
+// it holds, like an indicator.
+func Parse(expr string) (Expr, error) {
+	p := &parser{src: expr}
+	root, err := p.comparison()
+	if err != nil {
+		return Expr{}, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return Expr{}, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return Expr{root}, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// accept consumes the token if it comes next.
+func (p *parser) accept(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(token string) error {
+	if !p.accept(token) {
+		return p.errorf("expected %q", token)
+	}
+	return nil
+}
+
+func (p *parser) peekDigit() bool {
+	return p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9'
+}
+
+func (p *parser) number() (int, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.peekDigit() {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, p.errorf("expected a number")
+	}
+	return strconv.Atoi(p.src[start:p.pos])
+}
+
+func (p *parser) word() string {
+	start := p.pos
+	for p.pos < len(p.src) && (p.src[p.pos] >= 'a' && p.src[p.pos] <= 'z' || p.src[p.pos] >= 'A' && p.src[p.pos] <= 'Z') {
+		p.pos++
+	}
+	return strings.ToLower(p.src[start:p.pos])
+}
+
+var comparisonOps = []string{">=", "<=", "==", "!=", ">", "<", "="}
+
+func (p *parser) comparison() (node, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range comparisonOps {
+		if p.accept(op) {
+			right, err := p.sum()
+			if err != nil {
+				return nil, err
+			}
+			return comparison{op, left, right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) sum() (node, error) {
+	left, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op byte
+		switch {
+		case p.accept("+"):
+			op = '+'
+		case p.accept("-"):
+			op = '-'
+		default:
+			return left, nil
+		}
+		right, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op, left, right}
+	}
+}
+
+func (p *parser) product() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op byte
+		switch {
+		case p.accept("*"):
+			op = '*'
+		case p.accept("/"):
+			op = '/'
+		default:
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op, left, right}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if p.accept("-") {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return negate{x}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	p.skipSpace()
+	start := p.pos
+	switch {
+	case p.accept("("):
+		x, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(")")
+	case p.accept("["):
+		x, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := x.(comparison); !ok {
+			return nil, p.errorf("expected a comparison in [ ]")
+		}
+		return x, p.expect("]")
+	case p.peekDigit():
+		n, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.src) && (p.src[p.pos] == 'd' || p.src[p.pos] == 'D') {
+			return p.dice(n, start)
+		}
+		if p.accept("(") {
+			return p.repeat(n)
+		}
+		return number(n), nil
+	}
+	name := p.word()
+	switch name {
+	case "":
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("unexpected end of expression")
+		}
+		return nil, p.errorf("unexpected %q", p.src[p.pos:p.pos+1])
+	case "d":
+		p.pos = start
+		return p.dice(1, start)
+	}
+	return p.call(name)
+}
+
+func (p *parser) dice(count, start int) (node, error) {
+	p.pos++ // the d
+	if !p.peekDigit() {
+		return nil, p.errorf("expected the number of sides")
+	}
+	sides, err := p.number()
+	if err != nil {
+		return nil, err
+	}
+	if count < 1 || count > maxDice || sides < 1 {
+		return nil, p.errorf("cannot roll %dd%d", count, sides)
+	}
+	d := diceNode{count: count, sides: sides}
+	suffixStart := p.pos
+	if suffix := p.word(); suffix != "" {
+		k, ok := keepSuffixes[suffix]
+		if !ok {
+			p.pos = suffixStart
+			return nil, p.errorf("unknown modifier %q", suffix)
+		}
+		d.keep, d.n = k, 1
+		if p.peekDigit() {
+			if d.n, err = p.number(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	d.notation = strings.ToLower(strings.TrimSpace(p.src[start:p.pos]))
+	if !strings.HasPrefix(d.notation, strconv.Itoa(count)) {
+		d.notation = strconv.Itoa(count) + d.notation
+	}
+	return d, nil
+}
+
+func (p *parser) repeat(n int) (node, error) {
+	x, err := p.comparison()
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 || n > maxRepeats {
+		return nil, p.errorf("cannot repeat %d times", n)
+	}
+	return draws{"", n, x, sum}, p.expect(")")
+}
+
+func (p *parser) call(name string) (node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []node
+	for {
+		arg, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	switch name {
+	case "max":
+		return extremum{name, args, slices.Max[[]int]}, nil
+	case "min":
+		return extremum{name, args, slices.Min[[]int]}, nil
+	case "adv", "dis":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s takes one argument", name)
+		}
+		if name == "adv" {
+			return draws{name, 2, args[0], slices.Max[[]int]}, nil
+		}
+		return draws{name, 2, args[0], slices.Min[[]int]}, nil
+	case "best", "worst":
+		n, ok := args[0].(number)
+		if len(args) != 2 || !ok || n < 1 || n > maxRepeats {
+			return nil, fmt.Errorf("usage: %s(n, expression)", name)
+		}
+		if name == "best" {
+			return draws{name, int(n), args[1], slices.Max[[]int]}, nil
+		}
+		return draws{name, int(n), args[1], slices.Min[[]int]}, nil
+	}
+	return nil, fmt.Errorf("unknown function %q", name)
+}
@@ -0,0 +1,271 @@
+package dice
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hostettler.dev/dicestats"
+)
+
+// fixed returns the given rolls in order, failing the test if it runs out.
+func fixed(t *testing.T, rolls ...int) Roller {
+	return func(sides int) int {
+		if len(rolls) == 0 {
+			t.Fatal("rolled more dice than expected")
+		}
+		r := rolls[0]
+		rolls = rolls[1:]
+		if r < 1 || r > sides {
+			t.Fatalf("roll %d does not fit a d%d", r, sides)
+		}
+		return r
+	}
+}
+
+func TestRoll(t *testing.T) {
+	tests := []struct {
+		expr       string
+		rolls      []int
+		wantTotal  int
+		wantDetail string
+	}{
+		{"1d20 + 5", []int{14}, 19, "1d20: 14"},
+		{"d20", []int{7}, 7, "1d20: 7"},
+		{"4d6kh3", []int{6, 1, 5, 4}, 15, "4d6kh3: 6 (1) 5 4"},
+		{"4d6dl1", []int{3, 3, 2, 6}, 12, "4d6dl1: 3 3 (2) 6"},
+		{"2d20kl1", []int{18, 4}, 4, "2d20kl1: (18) 4"},
+		{"4d6dh1", []int{6, 6, 1, 2}, 9, "4d6dh1: (6) 6 1 2"},
+		{"adv(1d20) + 3", []int{5, 17}, 20, "1d20: 5, 1d20: 17"},
+		{"dis(1d20)", []int{5, 17}, 5, "1d20: 5, 1d20: 17"},
+		{"best(3, 1d20)", []int{2, 9, 4}, 9, "1d20: 2, 1d20: 9, 1d20: 4"},
+		{"3(max(3, 1d6 + 1))", []int{1, 5, 2}, 12, "1d6: 1, 1d6: 5, 1d6: 2"},
+		{"[1d20 + 7 >= 15] * (2d6 + 4)", []int{8, 3, 5}, 12, "1d20: 8, 2d6: 3 5"},
+		{"[1d20 + 7 >= 15] * (2d6 + 4)", []int{7, 3, 5}, 0, "1d20: 7, 2d6: 3 5"},
+		{"1d20 + 5 >= 15", []int{10}, 1, "1d20: 10"},
+		{"-1d4 + 10 / 3", []int{2}, 1, "1d4: 2"},
+		{"7 / -2", nil, -4, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := Roll(tt.expr, fixed(t, tt.rolls...))
+			if err != nil {
+				t.Fatalf("Roll(%q) failed: %s", tt.expr, err)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", got.Total, tt.wantTotal)
+			}
+			if got.Detail() != tt.wantDetail {
+				t.Errorf("Detail() = %q, want %q", got.Detail(), tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestRollRejectsMalformed(t *testing.T) {
+	tests := []struct{ expr, wantErr string }{
+		{"", "unexpected end"},
+		{"1d20 +", "unexpected end"},
+		{"2d", "number of sides"},
+		{"4d6xx", "unknown modifier"},
+		{"(1d6", `expected ")"`},
+		{"[1d20]", "comparison"},
+		{"foo(1)", "unknown function"},
+		{"best(1d4, 1d20)", "usage"},
+		{"5000d6", "cannot roll"},
+		{"1d6 / 0", "division by zero"},
+		{"1d6 1d6", "unexpected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Roll(tt.expr, Seeded(1))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Roll(%q) error = %v, want one containing %q", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeededIsReproducible(t *testing.T) {
+	first, err := Roll("10d20", Seeded(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := Roll("10d20", Seeded(42))
+	if first.Detail() != second.Detail() {
+		t.Errorf("rolls differ for the same seed: %q and %q", first.Detail(), second.Detail())
+	}
+}
+
+// readmeExamples are the expressions documented in the README, queried as
+// dicestats would be by ev or prob.
+var readmeExamples = []struct{ expr, query string }{
+	{"2d6", "E"},
+	{"4d6kh3", "E"},
+	{"1d20 + 5", "E"},
+	{"1d20 + 5 >= 15", "P"},
+	{"4d6kh3 + 2", "E"},
+	{"4d6dl1", "E"},
+	{"4d6kl3", "E"},
+	{"4d6dh1", "E"},
+	{"3(max(3, 1d6 + 1))", "E"},
+	{"best(3, 1d20)", "E"},
+	{"worst(2, 1d20)", "E"},
+	{"adv(1d20)", "E"},
+	{"dis(1d20)", "E"},
+	{"[1d20 + 7 >= 15] * (2d6 + 4)", "E"},
+	{"[1d20 + 2 >= 15] * (2d6 + 14)", "E"},
+	{"[1d20 > 15] * 8d6", "E"},
+	{"2d8 + 1d8", "E"},
+}
+
+// TestReadmeExamplesMatchDicestats keeps the grammar of Parse in line with
+// dicestats: every documented expression must be accepted by both.
+func TestReadmeExamplesMatchDicestats(t *testing.T) {
+	_, unavailable := dicestats.Query("E[1d6]")
+	for _, tt := range readmeExamples {
+		t.Run(tt.expr, func(t *testing.T) {
+			if _, err := Parse(tt.expr); err != nil {
+				t.Errorf("Parse(%q) = %v", tt.expr, err)
+			}
+			if unavailable != nil {
+				t.Skipf("dicestats is unavailable: %v", unavailable)
+			}
+			if _, err := dicestats.Query(tt.query + "[" + tt.expr + "]"); err != nil {
+				t.Errorf("dicestats.Query(%q) = %v", tt.expr, err)
+			}
+		})
+	}
+}
+
+func TestRollCritical(t *testing.T) {
+	e, err := Parse("1d8 + 2d6kh1 + 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := e.RollCritical(fixed(t, 5, 2, 3, 6, 1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != 20 {
+		t.Errorf("Total = %d, want 20", got.Total)
+	}
+	if want := "1d8: 5, 1d8: 2, 2d6kh1: (3) 6, 2d6kh1: (1) 4"; got.Detail() != want {
+		t.Errorf("Detail() = %q, want %q", got.Detail(), want)
+	}
+}
+
+func TestRollCriticalKeepsComparedDice(t *testing.T) {
+	e, err := Parse("[1d20 >= 10] * 1d6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := e.RollCritical(fixed(t, 12, 3, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != 7 {
+		t.Errorf("Total = %d, want 7", got.Total)
+	}
+}
+
+func TestExprString(t *testing.T) {
+	tests := []struct{ expr, want, wantCritical string }{
+		{"1d8 + 3", "1d8 + 3", "2(1d8) + 3"},
+		{"2d6kh1+d4", "2d6kh1 + 1d4", "2(2d6kh1) + 2(1d4)"},
+		{"(1d6 + 2) * 2 - (3 - 1d4)", "(1d6 + 2) * 2 - (3 - 1d4)", "(2(1d6) + 2) * 2 - (3 - 2(1d4))"},
+		{"-1d4 / 2", "-1d4 / 2", "-2(1d4) / 2"},
+		{"[1d20 + 7 >= 15] * (2d6 + 4)", "[1d20 + 7 >= 15] * (2d6 + 4)", "[1d20 + 7 >= 15] * (2(2d6) + 4)"},
+		{"1d20 + 5 >= 15", "[1d20 + 5 >= 15]", "[1d20 + 5 >= 15]"},
+		{"3(max(3, 1d6 + 1))", "3(max(3, 1d6 + 1))", "3(max(3, 2(1d6) + 1))"},
+		{"best(3, 1d20) + adv(d20) - worst(2, 1d4)", "best(3, 1d20) + adv(1d20) - worst(2, 1d4)",
+			"best(3, 2(1d20)) + adv(2(1d20)) - worst(2, 2(1d4))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := e.CriticalString(); got != tt.wantCritical {
+				t.Errorf("CriticalString() = %q, want %q", got, tt.wantCritical)
+			}
+			if _, err := Parse(e.CriticalString()); err != nil {
+				t.Errorf("Parse(CriticalString()) = %v", err)
+			}
+		})
+	}
+}
+
+func TestAttackChances(t *testing.T) {
+	tests := []struct {
+		name      string
+		bonus, ac int
+		mode      D20Mode
+		hit, crit float64
+	}{
+		{"needs 11", 5, 16, Straight, 0.5, 0.05},
+		{"natural 1 misses", 10, 5, Straight, 0.95, 0.05},
+		{"natural 20 hits", 0, 30, Straight, 0.05, 0.05},
+		{"advantage", 5, 16, Advantage, 0.75, 0.0975},
+		{"disadvantage", 5, 16, Disadvantage, 0.25, 0.0025},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, crit := AttackChances(tt.bonus, tt.ac, tt.mode)
+			if math.Abs(hit-tt.hit) > 1e-9 || math.Abs(crit-tt.crit) > 1e-9 {
+				t.Errorf("AttackChances() = %v, %v, want %v, %v", hit, crit, tt.hit, tt.crit)
+			}
+		})
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	got, err := Distribution("2d6")
+	if err != nil {
+		t.Skipf("dicestats is unavailable: %v", err)
+	}
+	if got.Min != 2 || got.Max != 12 || got.Mode != 7 || got.Median != 7 {
+		t.Errorf("Distribution(2d6) = %+v, want 2-12, mode and median 7", got)
+	}
+	if math.Abs(got.Mean-7) > 1e-9 || math.Abs(got.StdDev-math.Sqrt(35.0/6)) > 1e-9 || got.Approximate {
+		t.Errorf("Distribution(2d6) = %+v, want the exact mean 7 and std %.4f", got, math.Sqrt(35.0/6))
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	s, err := Distribution("2d6")
+	if err != nil {
+		t.Skipf("dicestats is unavailable: %v", err)
+	}
+	h, err := NewHistogram("2d6", s, 0, 12)
+	if err != nil {
+		t.Fatalf("NewHistogram(2d6) failed: %v", err)
+	}
+	if h.Width != 1 || len(h.Probs) != 13 || h.Probs[0] != 0 || math.Abs(h.Probs[7]-6.0/36) > 1e-9 {
+		t.Errorf("NewHistogram(2d6) = %+v, want 13 bars with P(7) = 1/6", h)
+	}
+}
+
+func TestHistogramFromSurvival(t *testing.T) {
+	// 1d4 from 0 to 7, two values per bar.
+	h := histogramFromSurvival(Stats{Min: 1, Max: 4}, 0, 2, []float64{1, 0.75, 0.25, 0, 0})
+	if want := []float64{0.25, 0.5, 0.25, 0}; !reflect.DeepEqual(h.Probs, want) {
+		t.Errorf("Probs = %v, want %v", h.Probs, want)
+	}
+	if lo, hi := h.Bar(1); lo != 2 || hi != 3 {
+		t.Errorf("Bar(1) = %d-%d, want 2-3", lo, hi)
+	}
+	for _, tt := range []struct {
+		q    float64
+		want int
+	}{{0.1, 0}, {0.25, 0}, {0.5, 1}, {0.75, 1}, {0.9, 2}} {
+		if got := h.Percentile(tt.q); got != tt.want {
+			t.Errorf("Percentile(%v) = %d, want %d", tt.q, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package dice
+
+import (
+	"fmt"
+
+	"hostettler.dev/dicestats"
+)
+
+// Stats summarizes the distribution of a dice expression.
+type Stats struct {
+	Mean, StdDev           float64
+	Min, Max, Mode, Median int
+	Approximate            bool
+}
+
+// Distribution queries dicestats once for the distribution of the
+// expression.
+func Distribution(expr string) (Stats, error) {
+	qr, err := dicestats.Query("D[" + expr + "]")
+	if err != nil {
+		return Stats{}, err
+	}
+	d := qr.Distribution
+	if d == nil {
+		return Stats{}, fmt.Errorf("no distribution for %s", expr)
+	}
+	return Stats{
+		Mean:        d.Expected(),
+		StdDev:      d.StdDev(),
+		Min:         d.Min(),
+		Max:         d.Max(),
+		Mode:        d.Mode(),
+		Median:      d.Median(),
+		Approximate: qr.Approximate,
+	}, nil
+}
+
+// MaxHistogramBars limits the bars of a histogram and with them the queries
+// made for it. Wider distributions group several values per bar.
+const MaxHistogramBars = 60
+
+// Histogram is the probability of the values of a dice expression from Lo
+// on, Width values per bar.
+type Histogram struct {
+	Stats
+	Lo, Width int
+	// Probs[i] is the probability of rolling a value of Bar(i).
+	Probs []float64
+}
+
+// NewHistogram queries dicestats for the probability of rolling each value
+// of the expression from lo to hi, given its statistics. Histograms of the
+// same range share their bars so they can be charted side by side.
+func NewHistogram(expr string, s Stats, lo, hi int) (Histogram, error) {
+	width := max(1, (hi-lo+MaxHistogramBars)/MaxHistogramBars)
+	bars := (hi-lo)/width + 1
+	// survival[i] is the probability of rolling at least the lowest value
+	// of bar i, survival[bars] stays 0.
+	survival := make([]float64, bars+1)
+	for i := range bars {
+		start := lo + i*width
+		switch {
+		case start > s.Max:
+		case start <= s.Min:
+			survival[i] = 1
+		default:
+			qr, err := dicestats.Query(fmt.Sprintf("P[(%s) >= %d]", expr, start))
+			if err != nil {
+				return Histogram{}, err
+			}
+			survival[i] = qr.Value
+			s.Approximate = s.Approximate || qr.Approximate
+		}
+	}
+	return histogramFromSurvival(s, lo, width, survival), nil
+}
+
+// histogramFromSurvival takes the difference of the chances to roll at least
+// the start of consecutive bars, normalized to add up to 1.
+func histogramFromSurvival(s Stats, lo, width int, survival []float64) Histogram {
+	h := Histogram{Stats: s, Lo: lo, Width: width, Probs: make([]float64, len(survival)-1)}
+	total := 0.0
+	for i := range h.Probs {
+		h.Probs[i] = max(0, survival[i]-survival[i+1])
+		total += h.Probs[i]
+	}
+	if total > 0 {
+		for i := range h.Probs {
+			h.Probs[i] /= total
+		}
+	}
+	return h
+}
+
+// Bar returns the lowest and highest value of bar i.
+func (h Histogram) Bar(i int) (lo, hi int) {
+	lo = h.Lo + i*h.Width
+	return lo, lo + h.Width - 1
+}
+
+// Percentile is the bar on which the chance of rolling at most its values
+// reaches q, e.g. the median for 0.5.
+func (h Histogram) Percentile(q float64) int {
+	total := 0.0
+	for i, p := range h.Probs {
+		total += p
+		// Tolerate the rounding of the summed probabilities.
+		if total >= q-1e-9 {
+			return i
+		}
+	}
+	return len(h.Probs) - 1
+}
+
+// Compare queries dicestats for the probability that a roll of a is greater
+// than an independent roll of b and the probability that both are equal.
+func Compare(a, b string) (greater, equal float64, err error) {
+	above, err := dicestats.Query(fmt.Sprintf("P[(%s) - (%s) >= 1]", a, b))
+	if err != nil {
+		return 0, 0, err
+	}
+	below, err := dicestats.Query(fmt.Sprintf("P[(%s) - (%s) >= 1]", b, a))
+	if err != nil {
+		return 0, 0, err
+	}
+	return above.Value, max(0, 1-above.Value-below.Value), nil
+}
+
+// D20Mode is how the d20 of an attack is rolled.
+type D20Mode int
+
+const (
+	Straight D20Mode = iota
+	Advantage
+	Disadvantage
+)
+
+// AttackChances returns the chance of an attack with the bonus to hit the AC
+// and the chance of a critical hit, which is part of the former. A natural 20
+// always hits, a natural 1 always misses.
+func AttackChances(bonus, ac int, mode D20Mode) (hit, crit float64) {
+	faces := min(max(21-max(ac-bonus, 2), 1), 19)
+	hit, crit = float64(faces)/20, 1.0/20
+	switch mode {
+	case Advantage:
+		return 1 - (1-hit)*(1-hit), 1 - (1-crit)*(1-crit)
+	case Disadvantage:
+		return hit * hit, crit * crit
+	}
+	return hit, crit
+}
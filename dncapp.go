@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 
 	"charm.land/bubbles/v2/key"
@@ -23,12 +25,16 @@ var defaultPadding = 2
 type DnCApp struct {
 	screen.FocusManager
 
-	config     util.Config
-	keymap     util.KeyMap
-	vim        *util.VimMode
-	width      int
-	height     int
-	db         *sqlx.DB
+	config util.Config
+	keymap util.KeyMap
+	vim    *util.VimMode
+	width  int
+	height int
+	db     *sqlx.DB
+	// dbCleanup removes the migrated snapshot of an outdated read-only
+	// database once db is closed.
+	dbCleanup  func()
+	readOnly   bool
 	ctx        context.Context
 	cancel     context.CancelFunc
 	cleanup    func()
@@ -52,11 +58,13 @@ type DnCApp struct {
 func NewApp(cfg util.Config, cleanup func()) (*DnCApp, error) {
 	km := cfg.KeyMap
 
-	handle, err := db.Open(cfg.DatabasePath)
+	dbPath, err := cfg.ActiveDatabasePath()
 	if err != nil {
 		return nil, err
 	}
-	if err := db.MigrateUp(handle); err != nil {
+	readOnly := cfg.ProfileReadOnly(cfg.Profile)
+	handle, dbCleanup, err := openDatabase(dbPath, readOnly)
+	if err != nil {
 		return nil, err
 	}
 	ctx, cancel := context.WithCancel(context.Background())
@@ -78,6 +86,8 @@ func NewApp(cfg util.Config, cleanup func()) (*DnCApp, error) {
 		config:             cfg,
 		keymap:             km,
 		db:                 handle,
+		dbCleanup:          dbCleanup,
+		readOnly:           readOnly,
 		vim:                vim,
 		ctx:                ctx,
 		cancel:             cancel,
@@ -102,15 +112,94 @@ func NewApp(cfg util.Config, cleanup func()) (*DnCApp, error) {
 		}),
 	}
 
+	app.titleScreen.SetProfiles(cfg.ProfileNames(), cfg.ActiveProfile())
+	app.titleScreen.SetReadOnly(readOnly)
+	app.palette.SetReadOnly(readOnly)
+
 	return app, nil
 }
 
+// openDatabase opens and migrates the database. Read-only databases cannot be
+// migrated, so one on an older schema is opened as a migrated snapshot, which
+// the returned cleanup removes after closing the database.
+func openDatabase(path string, readOnly bool) (*sqlx.DB, func(), error) {
+	noCleanup := func() {}
+	if readOnly {
+		handle, err := db.OpenReadOnly(path)
+		if err != nil {
+			return nil, noCleanup, err
+		}
+		err = db.CheckMigrated(handle)
+		if err == nil {
+			return handle, noCleanup, nil
+		}
+		_ = handle.Close()
+		if !errors.Is(err, db.ErrPendingMigrations) {
+			return nil, noCleanup, err
+		}
+		slog.Info("database schema is outdated, opening a migrated snapshot", "db", path, "error", err)
+		snapPath, cleanup, err := db.MigratedSnapshot(path)
+		if err != nil {
+			return nil, noCleanup, err
+		}
+		if handle, err = db.OpenReadOnly(snapPath); err != nil {
+			cleanup()
+			return nil, noCleanup, err
+		}
+		return handle, cleanup, nil
+	}
+	handle, err := db.Open(path)
+	if err != nil {
+		return nil, noCleanup, err
+	}
+	if err := db.MigrateUp(handle); err != nil {
+		_ = handle.Close()
+		return nil, noCleanup, err
+	}
+	return handle, noCleanup, nil
+}
+
+// switchProfile replaces the open database with the one of the given profile.
+// On failure the current database stays open.
+func (a *DnCApp) switchProfile(name string) tea.Cmd {
+	path, err := a.config.ProfileDatabasePath(name)
+	readOnly := a.config.ProfileReadOnly(name)
+	if err == nil {
+		var handle *sqlx.DB
+		var dbCleanup func()
+		if handle, dbCleanup, err = openDatabase(path, readOnly); err == nil {
+			_ = a.db.Close()
+			a.dbCleanup()
+			a.db = handle
+			a.dbCleanup = dbCleanup
+			a.repository = repository.NewDBCharacterRepository(handle)
+			a.config.Profile = name
+			a.readOnly = readOnly
+		}
+	}
+	if err != nil {
+		slog.Error("switching profile failed", "profile", name, "error", err)
+		msg := "Could not open profile " + name + ":\n" + err.Error()
+		var locked *db.LockedError
+		if errors.As(err, &locked) {
+			msg += "\n\nClose the other process or start dnc with --profile " + name + " to open a read-only snapshot."
+		}
+		return command.LaunchReaderScreenCmd(msg)
+	}
+	slog.Info("switched profile", "profile", name, "db", path, "readOnly", readOnly)
+	a.titleScreen.SetProfiles(a.config.ProfileNames(), name)
+	a.titleScreen.SetReadOnly(readOnly)
+	a.palette.SetReadOnly(readOnly)
+	return command.LoadSummariesRequest
+}
+
 func (a *DnCApp) Close() {
 	if a.cancel != nil {
 		a.cancel()
 	}
 	if a.db != nil {
 		_ = a.db.Close()
+		a.dbCleanup()
 	}
 	if a.cleanup != nil {
 		a.cleanup()
@@ -133,6 +222,11 @@ func (a *DnCApp) Init() tea.Cmd {
 }
 
 func (a *DnCApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if a.readOnly && blockedWhenReadOnly(msg) {
+		slog.Warn("ignoring write in read-only mode", "msg", fmt.Sprintf("%T", msg))
+		return a, nil
+	}
+
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
@@ -174,11 +268,22 @@ func (a *DnCApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case command.SwitchScreenMsg:
 		a.resetVimMode()
 		if a.router.IsModal(msg.Screen) {
+			if !a.router.IsFocused() {
+				// modals opened from the palette while the tabs hold focus
+				a.Blur()
+				a.router.Focus()
+			}
 			a.router.PushModal(msg.Screen)
 		} else {
 			a.router.SwitchContent(msg.Screen)
 			a.syncActiveTab()
 		}
+	case command.RunQuickActionMsg:
+		cmd = a.palette.Run(msg.Input)
+	case quickaction.RollAttackMsg:
+		cmd = a.palette.RollAttack(msg.Attack)
+	case command.SwitchProfileRequestMsg:
+		cmd = a.switchProfile(msg.Name)
 	case command.LoadSummariesRequestMsg:
 		cmd = repository.LoadSummariesCommand(a.repository, a.ctx)
 	case repository.LoadSummariesMsg:
@@ -223,6 +328,17 @@ func (a *DnCApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
+// blockedWhenReadOnly catches writes that slipped past the disabled key
+// bindings and quick actions.
+func blockedWhenReadOnly(msg tea.Msg) bool {
+	switch msg.(type) {
+	case command.WriteBackRequestMsg, command.CreateCharacterRequestMsg, command.DeleteCharacterRequestMsg,
+		command.LaunchConfirmationDialogueMsg, editor.EditValueMsg, editor.SwitchToEditorMsg:
+		return true
+	}
+	return false
+}
+
 func (a *DnCApp) View() tea.View {
 	screenContent := a.router.Active().View().Content
 
@@ -237,6 +353,9 @@ func (a *DnCApp) View() tea.View {
 		)
 		pageContent = lipgloss.JoinHorizontal(lipgloss.Left, tabs, pageContent)
 	}
+	if a.readOnly {
+		pageContent = lipgloss.JoinVertical(lipgloss.Center, styles.FlippedText.Render(" READ-ONLY "), pageContent)
+	}
 
 	pageWidth := a.width - defaultPadding
 	pageHeight := a.height - defaultPadding
@@ -269,12 +388,21 @@ func (a *DnCApp) View() tea.View {
 func (a *DnCApp) populateCharacterScreens(agg *repository.CharacterAggregate) tea.Cmd {
 	a.character = agg
 
+	km := a.keymap
+	if a.readOnly {
+		km = util.ReadOnlyKeyMap(km)
+	}
 	cmds := []tea.Cmd{
-		a.router.Register(command.StatScreenIndex, screen.NewStatScreen(a.keymap, agg), false),
-		a.router.Register(command.ProfileScreenIndex, screen.NewProfileScreen(a.keymap, agg), false),
-		a.router.Register(command.SpellScreenIndex, screen.NewSpellScreen(a.keymap, agg), false),
-		a.router.Register(command.InventoryScreenIndex, screen.NewInventoryScreen(a.keymap, agg), false),
-		a.router.Register(command.NoteScreenIndex, screen.NewNoteScreen(a.keymap, agg), false),
+		a.router.Register(command.StatScreenIndex, screen.NewStatScreen(km, agg), false),
+		a.router.Register(command.ProfileScreenIndex, screen.NewProfileScreen(km, agg), false),
+		a.router.Register(command.SpellScreenIndex, screen.NewSpellScreen(km, agg), false),
+		a.router.Register(command.InventoryScreenIndex, screen.NewInventoryScreen(km, agg), false),
+		a.router.Register(command.NoteScreenIndex, screen.NewNoteScreen(km, agg), false),
+		a.router.Register(command.SessionLogScreenIndex, screen.NewSessionLogScreen(km, agg), true),
+		a.router.Register(command.ClassScreenIndex, screen.NewClassScreen(km, agg), true),
+		a.router.Register(command.ConditionScreenIndex, screen.NewConditionScreen(km, agg), true),
+		a.router.Register(command.RollLogScreenIndex, screen.NewRollLogScreen(km, agg), true),
+		a.router.Register(command.MacroScreenIndex, screen.NewMacroScreen(km, agg), true),
 	}
 
 	a.palette.SetCharacter(agg)
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"hostettler.dev/dnc/repository"
+)
+
+// runDoctor implements `dnc doctor [-repair]`. It lists inconsistencies in the
+// database and fixes them if asked to.
+func runDoctor(dbPath string, readOnly bool, args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	repair := fs.Bool("repair", false, "fix the issues that were found")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *repair && readOnly {
+		return errors.New("cannot repair a read-only database")
+	}
+
+	handle, cleanup, err := openDatabase(dbPath, readOnly)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	defer handle.Close()
+	ctx := context.Background()
+	repo := repository.NewDBCharacterRepository(handle)
+
+	issues, err := repo.Diagnose(ctx)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("No issues found.")
+		return nil
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if !*repair {
+		fmt.Printf("%d issue(s) found. Run `dnc doctor -repair` to fix them.\n", len(issues))
+		return nil
+	}
+	slog.Info("doctor repair requested", "db", dbPath, "issues", len(issues))
+	if err := repo.Repair(ctx, issues); err != nil {
+		return err
+	}
+	fmt.Printf("Repaired %d issue(s).\n", len(issues))
+	return nil
+}
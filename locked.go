@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"hostettler.dev/dnc/db"
+	"hostettler.dev/dnc/util"
+)
+
+// offerSnapshot explains a lock conflict and asks whether to continue on a
+// read-only snapshot. DuckDB lets only one process write to a database and a
+// writer blocks readers too, so opening the locked file read-only would fail.
+func offerSnapshot(err error) bool {
+	var locked *db.LockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+	holder := "another process"
+	if locked.PID != 0 {
+		holder = fmt.Sprintf("%s (PID %d)", locked.Holder, locked.PID)
+	}
+	fmt.Printf("The database %s is in use by %s.\n", locked.Path, holder)
+	fmt.Print("Open a read-only snapshot of it instead? [y/N] ")
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// snapshotConfig points cfg at a read-only copy of its active database.
+func snapshotConfig(cfg util.Config, cleanup func()) (util.Config, func(), error) {
+	path, err := cfg.ActiveDatabasePath()
+	if err != nil {
+		return cfg, cleanup, err
+	}
+	snapPath, snapCleanup, err := db.Snapshot(path)
+	if err != nil {
+		return cfg, cleanup, err
+	}
+	cfg.DatabasePath = snapPath
+	cfg.Profile = ""
+	cfg.Profiles = nil
+	cfg.ReadOnly = true
+	return cfg, func() {
+		snapCleanup()
+		cleanup()
+	}, nil
+}
+
+// ensureUnlocked fails if another process has the database open.
+func ensureUnlocked(dbPath string) error {
+	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	handle, err := db.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	return handle.Close()
+}
@@ -17,6 +17,8 @@ func main() {
 	demo := flag.Bool("demo", false, "start with a temporary demo database")
 	backup := flag.String("backup", "", "copy database to specified file path")
 	restore := flag.String("restore", "", "overwrite database with specified file path")
+	profile := flag.String("profile", "", "name of the database profile to use")
+	readOnly := flag.Bool("read-only", false, "open the database without allowing modifications")
 	flag.Parse()
 
 	cfgDir := util.DefaultConfigDir()
@@ -26,7 +28,18 @@ func main() {
 	}
 	defer logCleanup()
 
-	dbPath := util.DefaultConfig(cfgDir).DatabasePath
+	fileCfg, err := util.LoadConfig(cfgDir)
+	if err != nil {
+		log.Fatal("failed to load config: ", err)
+	}
+	if *profile != "" {
+		fileCfg.Profile = *profile
+	}
+	fileCfg.ReadOnly = *readOnly
+	dbPath, err := fileCfg.ActiveDatabasePath()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	if *backup != "" {
 		slog.Info("backup requested", "src", dbPath, "dst", *backup)
@@ -38,6 +51,9 @@ func main() {
 	}
 
 	if *restore != "" {
+		if fileCfg.ProfileReadOnly(fileCfg.Profile) {
+			log.Fatal("restore failed: profile ", fileCfg.ActiveProfile(), " is read-only")
+		}
 		confirmation_string := "I am aware that this action overwrites all my current data"
 
 		fmt.Println("WARNING: This will overwrite all current data in your database.")
@@ -49,6 +65,9 @@ func main() {
 			fmt.Println("Aborted.")
 			os.Exit(1)
 		}
+		if err := ensureUnlocked(dbPath); err != nil {
+			log.Fatal("restore failed: ", err)
+		}
 		slog.Info("restore requested", "src", *restore, "dst", dbPath)
 		if err := util.CopyFile(*restore, dbPath); err != nil {
 			log.Fatal("restore failed: ", err)
@@ -57,15 +76,42 @@ func main() {
 		os.Exit(0)
 	}
 
-	slog.Info("dnc starting", "demo", *demo)
+	if flag.Arg(0) == "doctor" {
+		err := runDoctor(dbPath, fileCfg.ProfileReadOnly(fileCfg.Profile), flag.Args()[1:])
+		if offerSnapshot(err) {
+			snapCfg, snapCleanup, serr := snapshotConfig(fileCfg, func() {})
+			if serr != nil {
+				log.Fatal("doctor failed: ", serr)
+			}
+			err = runDoctor(snapCfg.DatabasePath, true, flag.Args()[1:])
+			snapCleanup()
+		}
+		if err != nil {
+			log.Fatal("doctor failed: ", err)
+		}
+		os.Exit(0)
+	}
+
+	slog.Info("dnc starting", "demo", *demo, "profile", fileCfg.ActiveProfile())
 
 	config, cleanup, err := util.GetConfig(cfgDir, *demo)
 	if err != nil {
 		slog.Error("failed to load config", "error", err)
 		log.Fatal(err)
 	}
+	if !config.Demo {
+		config.Profile = fileCfg.Profile
+		config.ReadOnly = fileCfg.ReadOnly
+	}
 
 	app, err := NewApp(config, cleanup)
+	if offerSnapshot(err) {
+		slog.Info("database locked, opening snapshot", "error", err)
+		config, cleanup, err = snapshotConfig(config, cleanup)
+		if err == nil {
+			app, err = NewApp(config, cleanup)
+		}
+	}
 	if err != nil {
 		slog.Error("failed to initialise app", "error", err)
 		log.Fatal(err)
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Condition is one of the conditions of the rules. CustomCondition is used
+// for conditions named by the user, e.g. from a homebrew spell.
+type Condition int
+
+const (
+	CustomCondition Condition = iota
+	Blinded
+	Charmed
+	Deafened
+	Frightened
+	Grappled
+	Incapacitated
+	Invisible
+	Paralyzed
+	Petrified
+	Poisoned
+	Prone
+	Restrained
+	Stunned
+	Unconscious
+)
+
+var conditionNames = []string{
+	"Custom", "Blinded", "Charmed", "Deafened", "Frightened", "Grappled", "Incapacitated", "Invisible",
+	"Paralyzed", "Petrified", "Poisoned", "Prone", "Restrained", "Stunned", "Unconscious",
+}
+
+func (c Condition) String() string {
+	if c < 0 || int(c) >= len(conditionNames) {
+		return ""
+	}
+	return conditionNames[c]
+}
+
+// KnownConditions lists all conditions except CustomCondition.
+func KnownConditions() []Condition {
+	conditions := make([]Condition, 0, len(conditionNames)-1)
+	for c := Blinded; int(c) < len(conditionNames); c++ {
+		conditions = append(conditions, c)
+	}
+	return conditions
+}
+
+var conditionSummaries = map[Condition]string{
+	Blinded:       "Can't see and fails checks requiring sight. Attack rolls against it have advantage, its attack rolls have disadvantage.",
+	Charmed:       "Can't attack the charmer or target it with harmful effects. The charmer has advantage on social checks against it.",
+	Deafened:      "Can't hear and fails checks requiring hearing.",
+	Frightened:    "Disadvantage on ability checks and attack rolls while the source of its fear is in sight. Can't willingly move closer to it.",
+	Grappled:      "Speed becomes 0. Ends if the grappler is incapacitated or it is moved out of reach.",
+	Incapacitated: "Can't take actions or reactions.",
+	Invisible:     "Can't be seen without magic or a special sense. Its attack rolls have advantage, attack rolls against it have disadvantage.",
+	Paralyzed:     "Incapacitated, can't move or speak. Fails Strength and Dexterity saves. Attacks against it have advantage, hits within 5 ft are critical.",
+	Petrified:     "Transformed into stone and incapacitated. Fails Strength and Dexterity saves, resistant to all damage, immune to poison and disease.",
+	Poisoned:      "Disadvantage on attack rolls and ability checks.",
+	Prone:         "Can only crawl. Disadvantage on attack rolls. Attacks within 5 ft have advantage against it, others disadvantage.",
+	Restrained:    "Speed becomes 0. Disadvantage on attack rolls and Dexterity saves, attack rolls against it have advantage.",
+	Stunned:       "Incapacitated, can't move and speaks falteringly. Fails Strength and Dexterity saves, attack rolls against it have advantage.",
+	Unconscious:   "Incapacitated, can't move or speak and drops what it holds. Falls prone, fails Strength and Dexterity saves, hits within 5 ft are critical.",
+}
+
+// Summary is a short summary of the rules of the condition, empty for
+// custom conditions.
+func (c Condition) Summary() string {
+	return conditionSummaries[c]
+}
+
+// RollKind is a kind of d20 roll.
+type RollKind int
+
+const (
+	AttackRoll RollKind = iota
+	AbilityCheck
+	SavingThrow
+)
+
+// RollEffect is how something, e.g. a condition, changes a d20 roll.
+type RollEffect int
+
+const (
+	NoEffect RollEffect = iota
+	Advantage
+	Disadvantage
+	AutoFail
+)
+
+var rollEffectNames = []string{"", "advantage", "disadvantage", "automatic failure"}
+
+func (e RollEffect) String() string {
+	if e < 0 || int(e) >= len(rollEffectNames) {
+		return ""
+	}
+	return rollEffectNames[e]
+}
+
+// RollEffect returns the effect of the condition on the roll of the
+// character having it. The ability is that of the check or saving throw.
+func (c Condition) RollEffect(kind RollKind, ability Ability) RollEffect {
+	switch {
+	case kind == AttackRoll && c == Invisible:
+		return Advantage
+	case kind == AttackRoll && (c == Blinded || c == Frightened || c == Poisoned || c == Prone || c == Restrained):
+		return Disadvantage
+	case kind == AbilityCheck && (c == Frightened || c == Poisoned):
+		return Disadvantage
+	case kind == SavingThrow && (ability == Strength || ability == Dexterity) &&
+		(c == Paralyzed || c == Petrified || c == Stunned || c == Unconscious):
+		return AutoFail
+	case kind == SavingThrow && ability == Dexterity && c == Restrained:
+		return Disadvantage
+	}
+	return NoEffect
+}
+
+// ConditionTO maps to the `character_condition` table.
+type ConditionTO struct {
+	ID          uuid.UUID `db:"id"`
+	CharacterID uuid.UUID `db:"character_id"`
+	Condition   int       `db:"condition"`
+	// Name is only used for custom conditions.
+	Name string `db:"name"`
+	// Duration is free text, e.g. "1 minute" or "until the end of its next turn".
+	Duration  string    `db:"duration"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Label is the name of the condition.
+func (c ConditionTO) Label() string {
+	if Condition(c.Condition) == CustomCondition {
+		return c.Name
+	}
+	return Condition(c.Condition).String()
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OptionalInt is an int that may be unset, stored as a nullable INTEGER.
+// Used for manual overrides of derived values: NULL means derived.
+type OptionalInt struct {
+	Int   int
+	Valid bool
+}
+
+func Override(v int) OptionalInt {
+	return OptionalInt{Int: v, Valid: true}
+}
+
+// Overrides sets every value of the list.
+func Overrides(values []int) OptionalIntList {
+	l := make(OptionalIntList, len(values))
+	for i, v := range values {
+		l[i] = Override(v)
+	}
+	return l
+}
+
+// Or returns the value if set and the fallback otherwise.
+func (o OptionalInt) Or(fallback int) int {
+	if o.Valid {
+		return o.Int
+	}
+	return fallback
+}
+
+// Scan implements sql.Scanner.
+func (o *OptionalInt) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptionalInt{}
+	case int32:
+		*o = Override(int(v))
+	case int64:
+		*o = Override(int(v))
+	default:
+		return fmt.Errorf("OptionalInt.Scan: unsupported source type %T", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (o OptionalInt) Value() (driver.Value, error) {
+	if !o.Valid {
+		return nil, nil
+	}
+	return int64(o.Int), nil
+}
+
+// OptionalIntList is a list of overrides stored as an INTEGER list whose
+// elements may be NULL.
+type OptionalIntList []OptionalInt
+
+// Scan implements sql.Scanner, see IntList.Scan.
+func (l *OptionalIntList) Scan(src any) error {
+	if src == nil {
+		*l = nil
+		return nil
+	}
+	v, ok := src.([]any)
+	if !ok {
+		return fmt.Errorf("OptionalIntList.Scan: unsupported source type %T", src)
+	}
+	out := make(OptionalIntList, len(v))
+	for i, e := range v {
+		if err := out[i].Scan(e); err != nil {
+			return err
+		}
+	}
+	*l = out
+	return nil
+}
+
+// Value implements driver.Valuer, see IntList.Value.
+func (l OptionalIntList) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, o := range l {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if o.Valid {
+			b.WriteString(strconv.Itoa(o.Int))
+		} else {
+			b.WriteString("NULL")
+		}
+	}
+	b.WriteByte(']')
+	return b.String(), nil
+}
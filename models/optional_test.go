@@ -0,0 +1,50 @@
+package models
+
+import "testing"
+
+func TestOptionalIntRoundTrip(t *testing.T) {
+	for _, o := range []OptionalInt{{}, Override(0), Override(-3)} {
+		v, err := o.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got OptionalInt
+		if i, ok := v.(int64); ok {
+			err = got.Scan(int32(i))
+		} else {
+			err = got.Scan(v)
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != o {
+			t.Errorf("round trip of %+v gave %+v", o, got)
+		}
+	}
+}
+
+func TestOptionalIntOr(t *testing.T) {
+	if got := (OptionalInt{}).Or(4); got != 4 {
+		t.Errorf("unset Or(4) = %d, want 4", got)
+	}
+	if got := Override(0).Or(4); got != 0 {
+		t.Errorf("Override(0).Or(4) = %d, want 0", got)
+	}
+}
+
+func TestOptionalIntListValue(t *testing.T) {
+	v, err := OptionalIntList{Override(3), {}, Override(-1)}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != "[3,NULL,-1]" {
+		t.Errorf("got %v, want [3,NULL,-1]", v)
+	}
+	var l OptionalIntList
+	if err := l.Scan([]any{int32(3), nil, int32(-1)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(l) != 3 || l[0] != Override(3) || l[1].Valid || l[2] != Override(-1) {
+		t.Errorf("unexpected scan result %v", l)
+	}
+}
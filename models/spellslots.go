@@ -0,0 +1,102 @@
+package models
+
+import "strings"
+
+// Spell slot progression. Full casters gain a new spell level every other
+// caster level, half and third casters progress at half and a third of
+// that speed. Artificers are half casters that round up, so they have
+// slots from level 1. Pact magic is tracked separately and does not count
+// towards the caster level.
+
+// CasterLevel combines the spellcasting classes into the caster level used
+// to look up spell slots. A single spellcasting class uses its own
+// progression, multiclassed characters round partial casters other than
+// artificers down.
+func CasterLevel(classes []CharacterClassTO) int {
+	var full, half, artificer, third, casters int
+	for _, c := range classes {
+		switch SpellcastingType(c.Spellcasting) {
+		case FullCaster:
+			full += c.Level
+		case HalfCaster:
+			if IsArtificer(c) {
+				artificer += c.Level
+			} else {
+				half += c.Level
+			}
+		case ThirdCaster:
+			third += c.Level
+		default:
+			continue
+		}
+		casters++
+	}
+	if casters == 1 {
+		switch {
+		case half >= 2:
+			return (half + 1) / 2
+		case third >= 3:
+			return (third + 2) / 3
+		}
+	}
+	return full + (artificer+1)/2 + half/2 + third/3
+}
+
+// IsArtificer reports whether the class is an artificer, which rounds its
+// half caster level up.
+func IsArtificer(c CharacterClassTO) bool {
+	return strings.EqualFold(strings.TrimSpace(c.ClassName), "artificer")
+}
+
+// SpellSlotsForCasterLevel returns the number of slots per spell level,
+// indexed 0-9 like CharacterTO.SpellSlotsUsed. Cantrips have no slots.
+func SpellSlotsForCasterLevel(level int) []int {
+	slots := make([]int, 10)
+	level = min(level, 20)
+	for s := 1; s <= 9; s++ {
+		// Spell level s becomes available at caster level 2s-1, then the
+		// number of slots grows by one per level up to a cap.
+		unlocked := 2*s - 1
+		if level < unlocked {
+			break
+		}
+		n := level - unlocked + 1
+		if s <= 3 {
+			n++
+		}
+		switch {
+		case s == 1:
+			n = min(n, 4)
+		case s <= 4:
+			n = min(n, 3)
+		case s == 5:
+			n = min(n, 2)
+		default:
+			n = 1
+		}
+		// 5th to 7th level slots gain one more at caster levels 18 to 20.
+		if s >= 5 && s <= 7 && level >= 13+s {
+			n++
+		}
+		slots[s] = n
+	}
+	return slots
+}
+
+// PactSlotsForLevel returns the number of pact magic slots and their spell
+// level for the given pact magic class level.
+func PactSlotsForLevel(level int) (slots, slotLevel int) {
+	switch {
+	case level <= 0:
+		return 0, 0
+	case level == 1:
+		slots = 1
+	case level <= 10:
+		slots = 2
+	case level <= 16:
+		slots = 3
+	default:
+		slots = 4
+	}
+	return slots, min((level+1)/2, 5)
+}
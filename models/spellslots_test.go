@@ -0,0 +1,71 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpellSlotsForCasterLevel(t *testing.T) {
+	tests := []struct {
+		level int
+		want  []int
+	}{
+		{0, []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{1, []int{0, 2, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{3, []int{0, 4, 2, 0, 0, 0, 0, 0, 0, 0}},
+		{5, []int{0, 4, 3, 2, 0, 0, 0, 0, 0, 0}},
+		{9, []int{0, 4, 3, 3, 3, 1, 0, 0, 0, 0}},
+		{12, []int{0, 4, 3, 3, 3, 2, 1, 0, 0, 0}},
+		{17, []int{0, 4, 3, 3, 3, 2, 1, 1, 1, 1}},
+		{18, []int{0, 4, 3, 3, 3, 3, 1, 1, 1, 1}},
+		{20, []int{0, 4, 3, 3, 3, 3, 2, 2, 1, 1}},
+		{25, []int{0, 4, 3, 3, 3, 3, 2, 2, 1, 1}},
+	}
+	for _, tt := range tests {
+		if got := SpellSlotsForCasterLevel(tt.level); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SpellSlotsForCasterLevel(%d) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestCasterLevel(t *testing.T) {
+	class := func(level int, s SpellcastingType) CharacterClassTO {
+		return CharacterClassTO{Level: level, Spellcasting: int(s)}
+	}
+	artificer := func(level int) CharacterClassTO {
+		return CharacterClassTO{ClassName: "Artificer", Level: level, Spellcasting: int(HalfCaster)}
+	}
+	tests := []struct {
+		name    string
+		classes []CharacterClassTO
+		want    int
+	}{
+		{"no classes", nil, 0},
+		{"full casters add up", []CharacterClassTO{class(5, FullCaster), class(2, FullCaster)}, 7},
+		{"single half caster rounds up", []CharacterClassTO{class(5, HalfCaster)}, 3},
+		{"half caster has no slots at level 1", []CharacterClassTO{class(1, HalfCaster)}, 0},
+		{"artificer has slots at level 1", []CharacterClassTO{artificer(1)}, 1},
+		{"artificer rounds up when multiclassed", []CharacterClassTO{artificer(3), class(2, FullCaster)}, 4},
+		{"single third caster rounds up", []CharacterClassTO{class(7, ThirdCaster)}, 3},
+		{"third caster has no slots before level 3", []CharacterClassTO{class(2, ThirdCaster)}, 0},
+		{"multiclass rounds partial casters down", []CharacterClassTO{class(5, HalfCaster), class(3, FullCaster), class(5, ThirdCaster)}, 6},
+		{"non-casters and pact magic are ignored", []CharacterClassTO{class(5, HalfCaster), class(4, NoSpellcasting), class(3, PactMagic)}, 3},
+	}
+	for _, tt := range tests {
+		if got := CasterLevel(tt.classes); got != tt.want {
+			t.Errorf("%s: CasterLevel() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPactSlotsForLevel(t *testing.T) {
+	tests := []struct{ level, slots, slotLevel int }{
+		{0, 0, 0}, {1, 1, 1}, {2, 2, 1}, {3, 2, 2}, {9, 2, 5}, {11, 3, 5}, {17, 4, 5}, {20, 4, 5},
+	}
+	for _, tt := range tests {
+		slots, slotLevel := PactSlotsForLevel(tt.level)
+		if slots != tt.slots || slotLevel != tt.slotLevel {
+			t.Errorf("PactSlotsForLevel(%d) = %d, %d, want %d, %d", tt.level, slots, slotLevel, tt.slots, tt.slotLevel)
+		}
+	}
+}
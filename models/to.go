@@ -8,44 +8,56 @@ import (
 
 // CharacterTO maps directly to the `character` table.
 type CharacterTO struct {
-	ID                  uuid.UUID `db:"id"`
-	Name                string    `db:"name"`
-	ClassLevels         string    `db:"class_levels"`
-	Race                string    `db:"race"`
-	Alignment           string    `db:"alignment"`
-	ProficiencyBonus    int       `db:"proficiency_bonus"`
-	ArmorClass          int       `db:"armor_class"`
-	Initiative          int       `db:"initiative"`
-	Speed               int       `db:"speed"`
-	MaxHitPoints        int       `db:"max_hit_points"`
-	CurrHitPoints       int       `db:"curr_hit_points"`
-	TempHitPoints       int       `db:"temp_hit_points"`
-	HitDice             string    `db:"hit_dice"`
-	UsedHitDice         string    `db:"used_hit_dice"`
-	DeathSaveSuccesses  int       `db:"death_save_successes"`
-	DeathSaveFailures   int       `db:"death_save_failures"`
-	Exhaustion          int       `db:"exhaustion"`
-	Concentration       int       `db:"concentration"`
-	Inspiration         int       `db:"inspiration"`
-	Condition           string    `db:"condition"`
-	Actions             string    `db:"actions"`
-	BonusActions        string    `db:"bonus_actions"`
-	SpellSlots          IntList   `db:"spell_slots"`
-	SpellSlotsUsed      IntList   `db:"spell_slots_used"`
-	SpellcastingAbility string    `db:"spellcasting_ability"`
-	SpellSaveDC         int       `db:"spell_save_dc"`
-	SpellAttackBonus    int       `db:"spell_attack_bonus"`
-	Age                 int       `db:"age"`
-	Height              string    `db:"height"`
-	Weight              string    `db:"weight"`
-	Eyes                string    `db:"eyes"`
-	Skin                string    `db:"skin"`
-	Hair                string    `db:"hair"`
-	Appearance          string    `db:"appearance"`
-	Backstory           string    `db:"backstory"`
-	Personality         string    `db:"personality"`
-	CreatedAt           time.Time `db:"created_at"`
-	UpdatedAt           time.Time `db:"updated_at"`
+	ID                 uuid.UUID   `db:"id"`
+	Name               string      `db:"name"`
+	Race               string      `db:"race"`
+	Alignment          string      `db:"alignment"`
+	ProficiencyBonus   OptionalInt `db:"proficiency_bonus"`
+	ArmorClass         OptionalInt `db:"armor_class"`
+	UnarmoredDefense   int         `db:"unarmored_defense"`
+	CoinWeight         int         `db:"coin_weight"`
+	VariantEncumbrance int         `db:"variant_encumbrance"`
+	AttunementLimit    int         `db:"attunement_limit"`
+	Initiative         OptionalInt `db:"initiative"`
+	InitiativeBonus    int         `db:"initiative_bonus"`
+	Speed              int         `db:"speed"`
+	MaxHitPoints       int         `db:"max_hit_points"`
+	CurrHitPoints      int         `db:"curr_hit_points"`
+	TempHitPoints      int         `db:"temp_hit_points"`
+	DeathSaveSuccesses int         `db:"death_save_successes"`
+	DeathSaveFailures  int         `db:"death_save_failures"`
+	Exhaustion         int         `db:"exhaustion"`
+	ExhaustionRules    int         `db:"exhaustion_rules"`
+	Concentration      int         `db:"concentration"`
+	// ConcentrationSpell is the name of the spell concentrated on, if known.
+	ConcentrationSpell   string          `db:"concentration_spell"`
+	Inspiration          int             `db:"inspiration"`
+	Actions              string          `db:"actions"`
+	BonusActions         string          `db:"bonus_actions"`
+	SpellSlotOverrides   OptionalIntList `db:"spell_slot_overrides"`
+	SpellSlotsUsed       IntList         `db:"spell_slots_used"`
+	PactSlots            OptionalInt     `db:"pact_slots"`
+	PactSlotLevel        OptionalInt     `db:"pact_slot_level"`
+	PactSlotsUsed        int             `db:"pact_slots_used"`
+	SpellcastingAbility  int             `db:"spellcasting_ability"`
+	SpellSaveDC          OptionalInt     `db:"spell_save_dc"`
+	SpellSaveDCItemBonus int             `db:"spell_save_dc_item_bonus"`
+	SpellAttackBonus     OptionalInt     `db:"spell_attack_bonus"`
+	SpellAttackItemBonus int             `db:"spell_attack_item_bonus"`
+	Age                  int             `db:"age"`
+	Height               string          `db:"height"`
+	Weight               string          `db:"weight"`
+	Eyes                 string          `db:"eyes"`
+	Skin                 string          `db:"skin"`
+	Hair                 string          `db:"hair"`
+	Appearance           string          `db:"appearance"`
+	Backstory            string          `db:"backstory"`
+	Personality          string          `db:"personality"`
+	PassivePerception    OptionalInt     `db:"passive_perception"`
+	PassiveInsight       OptionalInt     `db:"passive_insight"`
+	PassiveInvestigation OptionalInt     `db:"passive_investigation"`
+	CreatedAt            time.Time       `db:"created_at"`
+	UpdatedAt            time.Time       `db:"updated_at"`
 }
 
 // ItemTO maps to the `item` table.
@@ -56,10 +68,18 @@ type ItemTO struct {
 	IsEquippable    int       `db:"is_equippable"`
 	Equipped        int       `db:"equipped"`
 	AttunementSlots int       `db:"attunement_slots"`
+	Attuned         int       `db:"attuned"`
 	Quantity        int       `db:"quantity"`
 	Description     string    `db:"description"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
+	// Weight is in pounds per unit.
+	Weight float64 `db:"weight"`
+	// ArmorBaseAC is 0 for items that are not armor.
+	ArmorBaseAC  int         `db:"armor_base_ac"`
+	ArmorDexCap  OptionalInt `db:"armor_dex_cap"`
+	ShieldBonus  int         `db:"shield_bonus"`
+	MagicACBonus int         `db:"magic_ac_bonus"`
+	CreatedAt    time.Time   `db:"created_at"`
+	UpdatedAt    time.Time   `db:"updated_at"`
 }
 
 // WalletTO maps to the `wallet` table.
@@ -184,3 +204,69 @@ type NoteTO struct {
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
+
+// ResourceTO maps to the `resource` table. Used counts the spent charges,
+// the maximum is Maximum or, with a formula, the formula plus Maximum.
+type ResourceTO struct {
+	ID          uuid.UUID `db:"id"`
+	CharacterID uuid.UUID `db:"character_id"`
+	Name        string    `db:"name"`
+	Maximum     int       `db:"maximum"`
+	MaxFormula  int       `db:"max_formula"`
+	// MaxClass is the class whose level is used, the character level if empty.
+	MaxClass   string    `db:"max_class"`
+	MaxAbility int       `db:"max_ability"`
+	Used       int       `db:"used"`
+	Recharge   int       `db:"recharge"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// CharacterClassTO maps to the `character_class` table.
+type CharacterClassTO struct {
+	ID           uuid.UUID `db:"id"`
+	CharacterID  uuid.UUID `db:"character_id"`
+	ClassName    string    `db:"class_name"`
+	Subclass     string    `db:"subclass"`
+	Level        int       `db:"level"`
+	HitDie       int       `db:"hit_die"`
+	HitDiceUsed  int       `db:"hit_dice_used"`
+	Spellcasting int       `db:"spellcasting"`
+	// SpellcastingAbility overrides the one of the character, 0 if unset.
+	SpellcastingAbility int       `db:"spellcasting_ability"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
+}
+
+// SessionEventTO maps to the append-only `session_event` table.
+type SessionEventTO struct {
+	ID          uuid.UUID `db:"id"`
+	CharacterID uuid.UUID `db:"character_id"`
+	SessionID   uuid.UUID `db:"session_id"`
+	Actor       string    `db:"actor"`
+	Action      string    `db:"action"`
+	Args        string    `db:"args"`
+	Before      string    `db:"state_before"`
+	After       string    `db:"state_after"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// MacroTO maps to the `dice_macro` table.
+type MacroTO struct {
+	ID          uuid.UUID `db:"id"`
+	CharacterID uuid.UUID `db:"character_id"`
+	Name        string    `db:"name"`
+	Expression  string    `db:"expression"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// RollTO maps to the append-only `dice_roll` table.
+type RollTO struct {
+	ID          uuid.UUID `db:"id"`
+	CharacterID uuid.UUID `db:"character_id"`
+	Expression  string    `db:"expression"`
+	Total       int       `db:"total"`
+	Detail      string    `db:"detail"`
+	CreatedAt   time.Time `db:"created_at"`
+}
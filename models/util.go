@@ -26,6 +26,131 @@ const (
 	Temporary
 )
 
+// SpellcastingType describes how a class contributes to spell slots.
+type SpellcastingType int
+
+const (
+	NoSpellcasting SpellcastingType = iota
+	FullCaster
+	HalfCaster
+	ThirdCaster
+	PactMagic
+)
+
+// UnarmoredDefense is the armor class formula used without armor.
+type UnarmoredDefense int
+
+const (
+	StandardDefense  UnarmoredDefense = iota // 10 + Dex
+	BarbarianDefense                         // 10 + Dex + Con
+	MonkDefense                              // 10 + Dex + Wis, without a shield
+	NaturalArmor                             // 13 + Dex, e.g. Mage Armor
+)
+
+// Encumbrance is the load state of a character. Encumbered and
+// HeavilyEncumbered only apply with the variant encumbrance rules.
+type Encumbrance int
+
+const (
+	Unencumbered Encumbrance = iota
+	Encumbered
+	HeavilyEncumbered
+	OverCapacity
+)
+
+// ExhaustionRules selects how exhaustion affects a character.
+type ExhaustionRules int
+
+const (
+	TieredExhaustion  ExhaustionRules = iota // 2014: cumulative effects per level
+	PenaltyExhaustion                        // 2024: -2 per level to d20 tests, -5 ft speed
+)
+
+// ResourceFormula derives the maximum of a class resource.
+type ResourceFormula int
+
+const (
+	FixedMax            ResourceFormula = iota
+	LevelMax                            // class or character level, e.g. Ki
+	AbilityModifierMax                  // at least 1, e.g. Bardic Inspiration
+	ProficiencyBonusMax                 // e.g. features usable PB times
+)
+
+// Recharge is the rest on which a class resource is regained.
+type Recharge int
+
+const (
+	ShortRestRecharge Recharge = iota // also on a long rest
+	LongRestRecharge
+	DawnRecharge // assumed to pass during a long rest
+)
+
+// Ability identifies one of the six ability scores, e.g. the spellcasting
+// ability of a character or class.
+type Ability int
+
+const (
+	NoAbility Ability = iota
+	Strength
+	Dexterity
+	Constitution
+	Intelligence
+	Wisdom
+	Charisma
+)
+
+// AbilityCount is the number of abilities, NoAbility not counted.
+const AbilityCount = int(Charisma)
+
+var abilityNames = []string{"", "Strength", "Dexterity", "Constitution", "Intelligence", "Wisdom", "Charisma"}
+
+func (a Ability) String() string {
+	if a < 0 || int(a) >= len(abilityNames) {
+		return ""
+	}
+	return abilityNames[a]
+}
+
+// Short abbreviates the ability, e.g. "Dex".
+func (a Ability) Short() string {
+	name := a.String()
+	return name[:min(3, len(name))]
+}
+
+// ParseAbility parses an ability from its name or abbreviation, ignoring
+// case, e.g. "dex" or "Dexterity".
+func ParseAbility(s string) (Ability, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) < 3 {
+		return NoAbility, false
+	}
+	for i, name := range abilityNames[1:] {
+		if strings.HasPrefix(strings.ToLower(name), s) {
+			return Ability(i + 1), true
+		}
+	}
+	return NoAbility, false
+}
+
+// ToProficiencyByAbility returns the saving throw proficiency of an ability.
+func (s SavingThrowsTO) ToProficiencyByAbility(ability Ability) Proficiency {
+	switch ability {
+	case Strength:
+		return Proficiency(s.StrengthProficiency)
+	case Dexterity:
+		return Proficiency(s.DexterityProficiency)
+	case Constitution:
+		return Proficiency(s.ConstitutionProficiency)
+	case Intelligence:
+		return Proficiency(s.IntelligenceProficiency)
+	case Wisdom:
+		return Proficiency(s.WisdomProficiency)
+	case Charisma:
+		return Proficiency(s.CharismaProficiency)
+	}
+	return NoProficiency
+}
+
 func (c CharacterSkillDetailTO) ToCharacterSkillTO() CharacterSkillTO {
 	return CharacterSkillTO{
 		ID:             c.ID,
@@ -59,3 +184,8 @@ func (a AbilitiesTO) ToScoreByName(ability string) int {
 	}
 	return 0
 }
+
+// ProficiencyBonusForLevel is +2 at level 1 and grows by one every four levels.
+func ProficiencyBonusForLevel(level int) int {
+	return 2 + (max(level, 1)-1)/4
+}
@@ -66,3 +66,31 @@ func TestToScoreByName(t *testing.T) {
 		})
 	}
 }
+
+func TestProficiencyBonusForLevel(t *testing.T) {
+	for level, want := range map[int]int{0: 2, 1: 2, 4: 2, 5: 3, 8: 3, 9: 4, 13: 5, 17: 6, 20: 6} {
+		if got := ProficiencyBonusForLevel(level); got != want {
+			t.Errorf("ProficiencyBonusForLevel(%d) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestParseAbility(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Ability
+		wantOk bool
+	}{
+		{"dex", Dexterity, true},
+		{"Dexterity", Dexterity, true},
+		{" CHA ", Charisma, true},
+		{"wis", Wisdom, true},
+		{"st", NoAbility, false},
+		{"luck", NoAbility, false},
+	}
+	for _, tt := range tests {
+		if got, ok := ParseAbility(tt.in); got != tt.want || ok != tt.wantOk {
+			t.Errorf("ParseAbility(%q) = %v, %t, want %v, %t", tt.in, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
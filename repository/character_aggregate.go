@@ -2,6 +2,9 @@ package repository
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"hostettler.dev/dnc/models"
@@ -14,12 +17,21 @@ type CharacterAggregate struct {
 	Abilities    *models.AbilitiesTO
 	SavingThrows *models.SavingThrowsTO
 	Wallet       *models.WalletTO
+	Classes      []models.CharacterClassTO
+	Resources    []models.ResourceTO
+	Conditions   []models.ConditionTO
 	Items        []models.ItemTO
 	Spells       []models.SpellTO
 	Attacks      []models.AttackTO
 	Skills       []models.CharacterSkillDetailTO
 	Features     []models.FeatureTO
 	Notes        []models.NoteTO
+	Macros       []models.MacroTO
+	Events       []models.SessionEventTO
+	Rolls        []models.RollTO
+
+	// session groups the events recorded while this aggregate is loaded.
+	session uuid.UUID
 
 	// shadow is the last known persisted state. Set by the repository on
 	// load/create and refreshed after each successful Update. Compared
@@ -36,7 +48,7 @@ func (c *CharacterAggregate) Clone() *CharacterAggregate {
 	cp := &CharacterAggregate{}
 	if c.Character != nil {
 		ch := *c.Character
-		ch.SpellSlots = append(models.IntList(nil), c.Character.SpellSlots...)
+		ch.SpellSlotOverrides = append(models.OptionalIntList(nil), c.Character.SpellSlotOverrides...)
 		ch.SpellSlotsUsed = append(models.IntList(nil), c.Character.SpellSlotsUsed...)
 		cp.Character = &ch
 	}
@@ -52,17 +64,43 @@ func (c *CharacterAggregate) Clone() *CharacterAggregate {
 		w := *c.Wallet
 		cp.Wallet = &w
 	}
+	cp.Classes = append([]models.CharacterClassTO(nil), c.Classes...)
+	cp.Resources = append([]models.ResourceTO(nil), c.Resources...)
+	cp.Conditions = append([]models.ConditionTO(nil), c.Conditions...)
 	cp.Items = append([]models.ItemTO(nil), c.Items...)
 	cp.Spells = append([]models.SpellTO(nil), c.Spells...)
 	cp.Attacks = append([]models.AttackTO(nil), c.Attacks...)
 	cp.Skills = append([]models.CharacterSkillDetailTO(nil), c.Skills...)
 	cp.Features = append([]models.FeatureTO(nil), c.Features...)
 	cp.Notes = append([]models.NoteTO(nil), c.Notes...)
+	cp.Macros = append([]models.MacroTO(nil), c.Macros...)
+	cp.Events = append([]models.SessionEventTO(nil), c.Events...)
+	cp.Rolls = append([]models.RollTO(nil), c.Rolls...)
+	cp.session = c.session
 	return cp
 }
 
 // Helper methods - Modify TOs not database, changes have to be written back (See command.WriteBackRequest)
 
+func (c *CharacterAggregate) AddEmptyClass() uuid.UUID {
+	class := models.CharacterClassTO{ID: uuid.New(), Level: 1, HitDie: 8}
+	c.Classes = append(c.Classes, class)
+	return class.ID
+}
+
+func (c *CharacterAggregate) AddEmptyResource() uuid.UUID {
+	resource := models.ResourceTO{ID: uuid.New(), Maximum: 1, Recharge: int(models.LongRestRecharge)}
+	c.Resources = append(c.Resources, resource)
+	return resource.ID
+}
+
+// AddEmptyCondition adds a custom condition.
+func (c *CharacterAggregate) AddEmptyCondition() uuid.UUID {
+	condition := models.ConditionTO{ID: uuid.New(), Condition: int(models.CustomCondition)}
+	c.Conditions = append(c.Conditions, condition)
+	return condition.ID
+}
+
 func (c *CharacterAggregate) AddEmptyItem() uuid.UUID {
 	item := models.ItemTO{ID: uuid.New()}
 	c.Items = append(c.Items, item)
@@ -93,12 +131,36 @@ func (c *CharacterAggregate) AddEmptyNote() uuid.UUID {
 	return note.ID
 }
 
+func (c *CharacterAggregate) AddEmptyMacro() uuid.UUID {
+	macro := models.MacroTO{ID: uuid.New()}
+	c.Macros = append(c.Macros, macro)
+	return macro.ID
+}
+
 func (c *CharacterAggregate) DeleteAttack(id uuid.UUID) {
 	c.Attacks = util.Filter(c.Attacks, func(a models.AttackTO) bool {
 		return a.ID != id
 	})
 }
 
+func (c *CharacterAggregate) DeleteClass(id uuid.UUID) {
+	c.Classes = util.Filter(c.Classes, func(cl models.CharacterClassTO) bool {
+		return cl.ID != id
+	})
+}
+
+func (c *CharacterAggregate) DeleteResource(id uuid.UUID) {
+	c.Resources = util.Filter(c.Resources, func(r models.ResourceTO) bool {
+		return r.ID != id
+	})
+}
+
+func (c *CharacterAggregate) DeleteCondition(id uuid.UUID) {
+	c.Conditions = util.Filter(c.Conditions, func(cd models.ConditionTO) bool {
+		return cd.ID != id
+	})
+}
+
 func (c *CharacterAggregate) DeleteItem(id uuid.UUID) {
 	c.Items = util.Filter(c.Items, func(i models.ItemTO) bool {
 		return i.ID != id
@@ -123,6 +185,76 @@ func (c *CharacterAggregate) DeleteNote(id uuid.UUID) {
 	})
 }
 
+func (c *CharacterAggregate) DeleteMacro(id uuid.UUID) {
+	c.Macros = util.Filter(c.Macros, func(m models.MacroTO) bool {
+		return m.ID != id
+	})
+}
+
+// LogEvent records a gameplay action in the session log together with the
+// state it touched before and after.
+func (c *CharacterAggregate) LogEvent(action, args, before, after string) {
+	if c.session == uuid.Nil {
+		c.session = uuid.New()
+	}
+	c.Events = append(c.Events, models.SessionEventTO{
+		ID:        uuid.New(),
+		SessionID: c.session,
+		Actor:     c.Character.Name,
+		Action:    action,
+		Args:      args,
+		Before:    before,
+		After:     after,
+		CreatedAt: time.Now(),
+	})
+}
+
+// LogRoll records a dice roll in the roll log.
+func (c *CharacterAggregate) LogRoll(expression string, total int, detail string) {
+	c.Rolls = append(c.Rolls, models.RollTO{
+		ID:         uuid.New(),
+		Expression: expression,
+		Total:      total,
+		Detail:     detail,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// Sessions groups the logged events by play session, oldest session first.
+func (c *CharacterAggregate) Sessions() [][]*models.SessionEventTO {
+	var sessions [][]*models.SessionEventTO
+	index := map[uuid.UUID]int{}
+	for i := range c.Events {
+		e := &c.Events[i]
+		idx, ok := index[e.SessionID]
+		if !ok {
+			idx = len(sessions)
+			index[e.SessionID] = idx
+			sessions = append(sessions, nil)
+		}
+		sessions[idx] = append(sessions[idx], e)
+	}
+	return sessions
+}
+
+// TotalLevel is the character level, i.e. the sum of all class levels.
+func (c *CharacterAggregate) TotalLevel() int {
+	total := 0
+	for _, cl := range c.Classes {
+		total += cl.Level
+	}
+	return total
+}
+
+// ClassLevels summarizes the classes as e.g. "Wizard 5 / Cleric 2".
+func (c *CharacterAggregate) ClassLevels() string {
+	parts := make([]string, 0, len(c.Classes))
+	for _, cl := range c.Classes {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%s %d", cl.ClassName, cl.Level)))
+	}
+	return strings.Join(parts, " / ")
+}
+
 func (c *CharacterAggregate) GetSpellsByLevel(l int) []*models.SpellTO {
 	spells := []*models.SpellTO{}
 	for i := range c.Spells {
@@ -133,40 +265,350 @@ func (c *CharacterAggregate) GetSpellsByLevel(l int) []*models.SpellTO {
 	return spells
 }
 
+// LongRest restores hit points, spell slots and all resources, reduces
+// exhaustion by one level and regains up to half of the total hit dice (at
+// least one).
 func (c *CharacterAggregate) LongRest() {
 	ch := c.Character
-	ch.CurrHitPoints = ch.MaxHitPoints
 	ch.DeathSaveSuccesses = 0
 	ch.DeathSaveFailures = 0
 	for i := range ch.SpellSlotsUsed {
 		ch.SpellSlotsUsed[i] = 0
 	}
+	ch.PactSlotsUsed = 0
+	ch.Exhaustion = max(0, ch.Exhaustion-1)
+	ch.CurrHitPoints = c.HitPointMaximum()
+	c.recoverHitDice(max(1, c.TotalLevel()/2))
+	c.rechargeResources(models.ShortRestRecharge, models.LongRestRecharge, models.DawnRecharge)
+}
+
+// rechargeResources regains all charges of the resources recharging on one
+// of the given rests.
+func (c *CharacterAggregate) rechargeResources(recharges ...models.Recharge) {
+	for i := range c.Resources {
+		if slices.Contains(recharges, models.Recharge(c.Resources[i].Recharge)) {
+			c.Resources[i].Used = 0
+		}
+	}
+}
+
+// SpendResource uses one charge of a resource.
+func (c *CharacterAggregate) SpendResource(r *models.ResourceTO) error {
+	if c.ResourceRemaining(r) == 0 {
+		return fmt.Errorf("no %s left", r.Name)
+	}
+	r.Used++
+	return nil
+}
+
+// HitDicePool groups the hit dice of all classes sharing a die size.
+type HitDicePool struct {
+	Die   int
+	Total int
+	Used  int
+}
+
+func (p HitDicePool) Remaining() int {
+	return max(0, p.Total-p.Used)
+}
+
+// HitDice returns the hit dice per die size, largest first.
+func (c *CharacterAggregate) HitDice() []HitDicePool {
+	pools := []HitDicePool{}
+	for _, cl := range c.Classes {
+		i := slices.IndexFunc(pools, func(p HitDicePool) bool { return p.Die == cl.HitDie })
+		if i < 0 {
+			pools = append(pools, HitDicePool{Die: cl.HitDie})
+			i = len(pools) - 1
+		}
+		pools[i].Total += cl.Level
+		pools[i].Used += cl.HitDiceUsed
+	}
+	slices.SortFunc(pools, func(a, b HitDicePool) int { return b.Die - a.Die })
+	return pools
+}
+
+// recoverHitDice regains up to n spent hit dice, largest first.
+func (c *CharacterAggregate) recoverHitDice(n int) {
+	classes := util.Pointers(c.Classes)
+	slices.SortStableFunc(classes, func(a, b *models.CharacterClassTO) int { return b.HitDie - a.HitDie })
+	for _, cl := range classes {
+		regained := min(n, cl.HitDiceUsed)
+		cl.HitDiceUsed -= regained
+		n -= regained
+	}
+}
+
+// ShortRest spends one hit die of each given size, rolls it plus the
+// Constitution modifier (at least 0 per die) and heals by the total.
+// Pact slots and resources recharging on a short rest are regained.
+func (c *CharacterAggregate) ShortRest(dice []int, roll func(sides int) int) (rolls []int, healed int, err error) {
+	needed := map[int]int{}
+	for _, d := range dice {
+		needed[d]++
+	}
+	pools := c.HitDice()
+	for die, n := range needed {
+		i := slices.IndexFunc(pools, func(p HitDicePool) bool { return p.Die == die })
+		if i < 0 {
+			return nil, 0, fmt.Errorf("no d%d hit dice", die)
+		}
+		if left := pools[i].Remaining(); left < n {
+			return nil, 0, fmt.Errorf("not enough d%d hit dice (%d left)", die, left)
+		}
+	}
+
+	con := models.ToModifier(c.abilityScore("constitution"), models.NoProficiency, 0)
+	for _, d := range dice {
+		c.spendHitDie(d)
+		r := roll(d)
+		rolls = append(rolls, r)
+		healed += max(0, r+con)
+	}
+	c.Heal(healed)
+	c.Character.PactSlotsUsed = 0
+	c.rechargeResources(models.ShortRestRecharge)
+	return rolls, healed, nil
+}
+
+func (c *CharacterAggregate) spendHitDie(die int) {
+	for i := range c.Classes {
+		cl := &c.Classes[i]
+		if cl.HitDie == die && cl.HitDiceUsed < cl.Level {
+			cl.HitDiceUsed++
+			return
+		}
+	}
 }
 
 func (c *CharacterAggregate) Heal(amount int) {
 	ch := c.Character
-	ch.CurrHitPoints = min(ch.CurrHitPoints+amount, ch.MaxHitPoints)
+	ch.CurrHitPoints = min(ch.CurrHitPoints+amount, c.HitPointMaximum())
 }
 
+// TakeDamage takes the damage from the temporary hit points first.
+// Concentration ends at 0 hit points.
 func (c *CharacterAggregate) TakeDamage(amount int) {
 	ch := c.Character
 	spill := max(0, -(ch.TempHitPoints - amount))
 	ch.TempHitPoints = max(ch.TempHitPoints-amount, 0)
 	ch.CurrHitPoints = max(ch.CurrHitPoints-spill, 0)
+	if ch.CurrHitPoints == 0 {
+		c.EndConcentration()
+	}
 }
 
 func (c *CharacterAggregate) SetTempHP(amount int) {
 	c.Character.TempHitPoints = amount
 }
 
+// CastSpell uses a spell slot of the given level. Pact magic slots are used
+// when no regular slot is left and they are of at least that level.
 func (c *CharacterAggregate) CastSpell(level int) error {
 	ch := c.Character
-	if level >= len(ch.SpellSlots) || ch.SpellSlots[level] <= 0 {
+	slots := c.SpellSlots()
+	pactSlots, pactLevel := c.PactSlots()
+	hasSlots := level < len(slots) && level < len(ch.SpellSlotsUsed) && slots[level] > 0
+	hasPactSlots := level > 0 && pactSlots > 0 && pactLevel >= level
+	if !hasSlots && !hasPactSlots {
 		return fmt.Errorf("no spell slots at level %d", level)
 	}
-	if ch.SpellSlotsUsed[level] >= ch.SpellSlots[level] {
-		return fmt.Errorf("no available slots at level %d", level)
+	if hasSlots && ch.SpellSlotsUsed[level] < slots[level] {
+		ch.SpellSlotsUsed[level]++
+		return nil
+	}
+	if hasPactSlots && ch.PactSlotsUsed < pactSlots {
+		ch.PactSlotsUsed++
+		return nil
+	}
+	return fmt.Errorf("no available slots at level %d", level)
+}
+
+// Cast casts a spell at the given level, cantrips use no slot. Casting a
+// concentration spell ends the current concentration, the name of the
+// replaced spell is returned.
+func (c *CharacterAggregate) Cast(spell *models.SpellTO, level int) (replaced string, err error) {
+	if level < spell.Level {
+		return "", fmt.Errorf("%s is a level %d spell", spell.Name, spell.Level)
+	}
+	if level > 0 {
+		if err := c.CastSpell(level); err != nil {
+			return "", err
+		}
+	}
+	if spell.Concentration == 1 {
+		replaced = c.Concentrate(spell.Name)
+	}
+	return replaced, nil
+}
+
+// Concentrate starts concentrating on the named spell. It returns the spell
+// concentrated on before, empty if there was none or it is the same.
+func (c *CharacterAggregate) Concentrate(spell string) (replaced string) {
+	if c.Concentrating() && !strings.EqualFold(c.Character.ConcentrationSpell, spell) {
+		replaced = c.ConcentrationLabel()
+	}
+	c.Character.Concentration = 1
+	c.Character.ConcentrationSpell = spell
+	return replaced
+}
+
+func (c *CharacterAggregate) Concentrating() bool {
+	return c.Character.Concentration == 1
+}
+
+// ConcentrationLabel names what the character concentrates on, "a spell"
+// if the spell is not known.
+func (c *CharacterAggregate) ConcentrationLabel() string {
+	if c.Character.ConcentrationSpell == "" {
+		return "a spell"
+	}
+	return c.Character.ConcentrationSpell
+}
+
+func (c *CharacterAggregate) EndConcentration() {
+	c.Character.Concentration = 0
+	c.Character.ConcentrationSpell = ""
+}
+
+// ConcentrationSaveDC is the DC of the Constitution save to keep
+// concentrating after taking damage.
+func ConcentrationSaveDC(damage int) int {
+	return max(10, damage/2)
+}
+
+// DefaultAttunementLimit is the number of attunement slots of most
+// characters, artificers raise it at higher levels.
+const DefaultAttunementLimit = 3
+
+// AttunedSlots counts the attunement slots taken by attuned items.
+func (c *CharacterAggregate) AttunedSlots() int {
+	used := 0
+	for _, it := range c.Items {
+		if it.Attuned == 1 {
+			used += it.AttunementSlots
+		}
+	}
+	return used
+}
+
+// FindItem looks up an item by its name, ignoring case. A unique prefix is
+// enough.
+func (c *CharacterAggregate) FindItem(name string) (*models.ItemTO, error) {
+	return findByName(c.Items, "item", func(it *models.ItemTO) string { return it.Name }, name)
+}
+
+// FindSpell looks up a spell by its name like FindItem.
+func (c *CharacterAggregate) FindSpell(name string) (*models.SpellTO, error) {
+	return findByName(c.Spells, "spell", func(s *models.SpellTO) string { return s.Name }, name)
+}
+
+// FindAttack looks up an attack by its name like FindItem.
+func (c *CharacterAggregate) FindAttack(name string) (*models.AttackTO, error) {
+	return findByName(c.Attacks, "attack", func(a *models.AttackTO) string { return a.Name }, name)
+}
+
+// FindSkill looks up a skill by its name like FindItem.
+func (c *CharacterAggregate) FindSkill(name string) (*models.CharacterSkillDetailTO, error) {
+	return findByName(c.Skills, "skill", func(s *models.CharacterSkillDetailTO) string { return s.SkillName }, name)
+}
+
+// Macro looks up a macro by its exact name ignoring case, unlike FindItem
+// as a prefix could be the start of a dice expression.
+func (c *CharacterAggregate) Macro(name string) (*models.MacroTO, bool) {
+	name = strings.TrimSpace(name)
+	for i := range c.Macros {
+		if strings.EqualFold(c.Macros[i].Name, name) {
+			return &c.Macros[i], true
+		}
+	}
+	return nil, false
+}
+
+// CheckMacroName rejects an empty name and the name of another macro.
+func (c *CharacterAggregate) CheckMacroName(id uuid.UUID, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("a macro needs a name")
+	}
+	if m, ok := c.Macro(name); ok && m.ID != id {
+		return fmt.Errorf("a macro named %s exists", m.Name)
+	}
+	return nil
+}
+
+func findByName[T any](elems []T, kind string, nameOf func(*T) string, name string) (*T, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	var matches []*T
+	for i := range elems {
+		elemName := strings.ToLower(nameOf(&elems[i]))
+		if elemName == name {
+			return &elems[i], nil
+		}
+		if strings.HasPrefix(elemName, name) {
+			matches = append(matches, &elems[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s named %q", kind, name)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("%q matches %d %ss", name, len(matches), kind)
+}
+
+// Attune attunes the item if it requires attunement and the limit allows it.
+func (c *CharacterAggregate) Attune(item *models.ItemTO) error {
+	switch {
+	case item.AttunementSlots == 0:
+		return fmt.Errorf("%s does not require attunement", item.Name)
+	case item.Attuned == 1:
+		return fmt.Errorf("already attuned to %s", item.Name)
+	case c.AttunedSlots()+item.AttunementSlots > c.Character.AttunementLimit:
+		return fmt.Errorf("attuning to %s exceeds the limit of %d", item.Name, c.Character.AttunementLimit)
 	}
-	ch.SpellSlotsUsed[level]++
+	item.Attuned = 1
 	return nil
 }
+
+// ActiveConditions returns the known conditions of the character, custom
+// conditions are left out.
+func (c *CharacterAggregate) ActiveConditions() []models.Condition {
+	var active []models.Condition
+	for _, cd := range c.Conditions {
+		if models.Condition(cd.Condition) != models.CustomCondition {
+			active = append(active, models.Condition(cd.Condition))
+		}
+	}
+	return active
+}
+
+// SetConditions replaces the known conditions of the character. Conditions
+// already present keep their duration, custom conditions are left as is.
+func (c *CharacterAggregate) SetConditions(conditions []models.Condition) {
+	c.Conditions = util.Filter(c.Conditions, func(cd models.ConditionTO) bool {
+		return models.Condition(cd.Condition) == models.CustomCondition ||
+			slices.Contains(conditions, models.Condition(cd.Condition))
+	})
+	for _, cond := range conditions {
+		if !c.HasCondition(cond) {
+			c.Conditions = append(c.Conditions, models.ConditionTO{ID: uuid.New(), Condition: int(cond)})
+		}
+	}
+}
+
+func (c *CharacterAggregate) HasCondition(cond models.Condition) bool {
+	return slices.ContainsFunc(c.Conditions, func(cd models.ConditionTO) bool {
+		return models.Condition(cd.Condition) == cond
+	})
+}
+
+// ConditionLabels joins the names of all conditions, e.g. "Poisoned, Prone".
+func (c *CharacterAggregate) ConditionLabels() string {
+	labels := make([]string, 0, len(c.Conditions))
+	for _, cd := range c.Conditions {
+		labels = append(labels, cd.Label())
+	}
+	return strings.Join(labels, ", ")
+}
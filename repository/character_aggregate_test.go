@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -117,6 +118,72 @@ func TestDeleteNote(t *testing.T) {
 	}
 }
 
+func TestMacro(t *testing.T) {
+	agg := newTestAggregate()
+	id := agg.AddEmptyMacro()
+	agg.Macros[0].Name = "Smite"
+	agg.Macros[0].Expression = "2d8 + 1d8"
+
+	if m, ok := agg.Macro(" smite "); !ok || m.Expression != "2d8 + 1d8" {
+		t.Errorf("Macro(smite) = %v, %t", m, ok)
+	}
+	if _, ok := agg.Macro("sm"); ok {
+		t.Error("expected a prefix not to match")
+	}
+	agg.DeleteMacro(id)
+	if len(agg.Macros) != 0 {
+		t.Errorf("expected 0 macros, got %d", len(agg.Macros))
+	}
+}
+
+func TestCheckMacroName(t *testing.T) {
+	agg := newTestAggregate()
+	smite := agg.AddEmptyMacro()
+	agg.Macros[0].Name = "Smite"
+	other := agg.AddEmptyMacro()
+
+	tests := []struct {
+		id      uuid.UUID
+		name    string
+		wantErr string
+	}{
+		{other, "Hex", ""},
+		{smite, " smite ", ""},
+		{other, "  ", "needs a name"},
+		{other, "SMITE", "named Smite exists"},
+	}
+	for _, tt := range tests {
+		err := agg.CheckMacroName(tt.id, tt.name)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("CheckMacroName(%q) = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestClassLevels(t *testing.T) {
+	agg := newTestAggregate()
+	if agg.ClassLevels() != "" || agg.TotalLevel() != 0 {
+		t.Errorf("expected no levels, got %q (%d)", agg.ClassLevels(), agg.TotalLevel())
+	}
+	id := agg.AddEmptyClass()
+	agg.Classes[0].ClassName = "Wizard"
+	agg.Classes[0].Level = 5
+	agg.AddEmptyClass()
+	agg.Classes[1].ClassName = "Cleric"
+	agg.Classes[1].Level = 2
+
+	if got := agg.ClassLevels(); got != "Wizard 5 / Cleric 2" {
+		t.Errorf("ClassLevels() = %q, want %q", got, "Wizard 5 / Cleric 2")
+	}
+	if got := agg.TotalLevel(); got != 7 {
+		t.Errorf("TotalLevel() = %d, want 7", got)
+	}
+	agg.DeleteClass(id)
+	if got := agg.ClassLevels(); got != "Cleric 2" {
+		t.Errorf("ClassLevels() after delete = %q, want %q", got, "Cleric 2")
+	}
+}
+
 func TestGetSpellsByLevel(t *testing.T) {
 	agg := newTestAggregate()
 	agg.AddEmptySpell(0)
@@ -160,11 +227,17 @@ func TestLongRest(t *testing.T) {
 	c.MaxHitPoints = 50
 	c.DeathSaveSuccesses = 2
 	c.DeathSaveFailures = 1
-	c.SpellSlots = []int{0, 4, 2}
+	c.SpellSlotOverrides = models.Overrides([]int{0, 4, 2})
+	c.PactSlotsUsed = 1
 	c.SpellSlotsUsed = []int{0, 4, 2}
+	c.Exhaustion = 2
 
 	agg.LongRest()
 
+	if c.Exhaustion != 1 {
+		t.Errorf("Exhaustion = %d, want 1", c.Exhaustion)
+	}
+
 	if c.CurrHitPoints != c.MaxHitPoints {
 		t.Errorf("CurrHitPoints = %d, want %d", c.CurrHitPoints, c.MaxHitPoints)
 	}
@@ -176,6 +249,85 @@ func TestLongRest(t *testing.T) {
 			t.Errorf("SpellSlotsUsed[%d] = %d, want 0", i, used)
 		}
 	}
+	if c.PactSlotsUsed != 0 {
+		t.Errorf("PactSlotsUsed = %d, want 0", c.PactSlotsUsed)
+	}
+}
+
+func TestRestsRechargeResources(t *testing.T) {
+	agg := newTestAggregate()
+	agg.Resources = []models.ResourceTO{
+		{Name: "Ki", Maximum: 5, Used: 5, Recharge: int(models.ShortRestRecharge)},
+		{Name: "Rage", Maximum: 3, Used: 2, Recharge: int(models.LongRestRecharge)},
+		{Name: "Wand", Maximum: 7, Used: 4, Recharge: int(models.DawnRecharge)},
+	}
+	agg.Character.PactSlotsUsed = 2
+
+	if _, _, err := agg.ShortRest(nil, nil); err != nil {
+		t.Fatalf("ShortRest() failed: %s", err)
+	}
+	if agg.Character.PactSlotsUsed != 0 {
+		t.Errorf("PactSlotsUsed = %d after short rest, want 0", agg.Character.PactSlotsUsed)
+	}
+	if got := []int{agg.Resources[0].Used, agg.Resources[1].Used, agg.Resources[2].Used}; !reflect.DeepEqual(got, []int{0, 2, 4}) {
+		t.Errorf("used after short rest = %v, want [0 2 4]", got)
+	}
+
+	agg.LongRest()
+	for _, r := range agg.Resources {
+		if r.Used != 0 {
+			t.Errorf("%s: Used = %d after long rest, want 0", r.Name, r.Used)
+		}
+	}
+}
+
+func TestSpendResource(t *testing.T) {
+	agg := newTestAggregate()
+	r := &models.ResourceTO{Name: "Bardic Inspiration", Maximum: 1}
+	if err := agg.SpendResource(r); err != nil {
+		t.Fatalf("SpendResource() failed: %s", err)
+	}
+	if err := agg.SpendResource(r); err == nil || r.Used != 1 {
+		t.Errorf("expected an error once spent, got %v with %d used", err, r.Used)
+	}
+}
+
+func TestLongRestRecoversHalfTheHitDice(t *testing.T) {
+	agg := newTestAggregate()
+	agg.Classes = []models.CharacterClassTO{
+		{ClassName: "Wizard", Level: 5, HitDie: 6, HitDiceUsed: 5},
+		{ClassName: "Fighter", Level: 2, HitDie: 10, HitDiceUsed: 1},
+	}
+
+	agg.LongRest()
+
+	// Half of 7 levels regains 3 dice, the d10 first.
+	want := []HitDicePool{{Die: 10, Total: 2, Used: 0}, {Die: 6, Total: 5, Used: 3}}
+	if got := agg.HitDice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("HitDice() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetConditions(t *testing.T) {
+	agg := newTestAggregate()
+	agg.Conditions = []models.ConditionTO{
+		{Condition: int(models.Poisoned), Duration: "1 hour"},
+		{Condition: int(models.CustomCondition), Name: "Hexed"},
+		{Condition: int(models.Prone)},
+	}
+
+	agg.SetConditions([]models.Condition{models.Poisoned, models.Blinded})
+
+	// Custom conditions and the duration of kept ones survive the toggle.
+	if got := agg.ConditionLabels(); got != "Poisoned, Hexed, Blinded" {
+		t.Errorf("ConditionLabels() = %q, want %q", got, "Poisoned, Hexed, Blinded")
+	}
+	if agg.Conditions[0].Duration != "1 hour" {
+		t.Errorf("Duration = %q, want %q", agg.Conditions[0].Duration, "1 hour")
+	}
+	if got := agg.ActiveConditions(); !reflect.DeepEqual(got, []models.Condition{models.Poisoned, models.Blinded}) {
+		t.Errorf("ActiveConditions() = %v", got)
+	}
 }
 
 func TestHeal(t *testing.T) {
@@ -238,6 +390,43 @@ func TestTakeDamage(t *testing.T) {
 	}
 }
 
+func TestConcentration(t *testing.T) {
+	agg := newTestAggregate()
+	agg.Character.CurrHitPoints = 20
+	agg.Character.SpellSlotsUsed = []int{0, 0}
+	agg.Character.SpellSlotOverrides = models.Overrides([]int{0, 1})
+	bless := &models.SpellTO{Name: "Bless", Level: 1, Concentration: 1}
+	guidance := &models.SpellTO{Name: "Guidance", Concentration: 1}
+
+	if replaced, err := agg.Cast(bless, 1); err != nil || replaced != "" {
+		t.Fatalf("Cast(Bless) = %q, %v", replaced, err)
+	}
+	if replaced, err := agg.Cast(guidance, 0); err != nil || replaced != "Bless" {
+		t.Errorf("Cast(Guidance) = %q, %v, want Bless replaced", replaced, err)
+	}
+	// Cantrips use no slot.
+	if agg.Character.SpellSlotsUsed[1] != 1 {
+		t.Errorf("SpellSlotsUsed[1] = %d, want 1", agg.Character.SpellSlotsUsed[1])
+	}
+
+	agg.TakeDamage(5)
+	if !agg.Concentrating() {
+		t.Error("expected concentration to hold above 0 HP")
+	}
+	agg.TakeDamage(15)
+	if agg.Concentrating() || agg.Character.ConcentrationSpell != "" {
+		t.Errorf("expected concentration to end at 0 HP, got %q", agg.Character.ConcentrationSpell)
+	}
+}
+
+func TestConcentrationSaveDC(t *testing.T) {
+	for damage, want := range map[int]int{1: 10, 21: 10, 22: 11, 45: 22} {
+		if got := ConcentrationSaveDC(damage); got != want {
+			t.Errorf("ConcentrationSaveDC(%d) = %d, want %d", damage, got, want)
+		}
+	}
+}
+
 func TestCastSpell(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -256,7 +445,7 @@ func TestCastSpell(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			agg := newTestAggregate()
-			agg.Character.SpellSlots = tt.slots
+			agg.Character.SpellSlotOverrides = models.Overrides(tt.slots)
 			agg.Character.SpellSlotsUsed = tt.used
 
 			err := agg.CastSpell(tt.level)
@@ -279,3 +468,27 @@ func TestCastSpell(t *testing.T) {
 		})
 	}
 }
+
+func TestLogEventGroupsBySession(t *testing.T) {
+	agg := newTestAggregate()
+	agg.Character.Name = "Bobby"
+	agg.LogEvent("dmg", "5", "HP 10/10", "HP 5/10")
+	agg.LogEvent("heal", "2", "HP 5/10", "HP 7/10")
+
+	// A reload starts a new session.
+	reloaded := agg.Clone()
+	reloaded.session = uuid.New()
+	reloaded.LogEvent("longrest", "", "HP 7/10", "HP 10/10")
+
+	sessions := reloaded.Sessions()
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	if len(sessions[0]) != 2 || len(sessions[1]) != 1 {
+		t.Errorf("session sizes = %d, %d, want 2, 1", len(sessions[0]), len(sessions[1]))
+	}
+	first := sessions[0][0]
+	if first.Actor != "Bobby" || first.Action != "dmg" || first.Before != "HP 10/10" || first.After != "HP 5/10" {
+		t.Errorf("unexpected first event: %+v", *first)
+	}
+}
@@ -27,30 +27,41 @@ func (r *DBCharacterRepository) create(ctx context.Context, agg *CharacterAggreg
 		ensureSpellSlots(c)
 		query := `
             INSERT INTO character (
-                name, class_levels, race, alignment,
+                name, race, alignment,
                 proficiency_bonus, armor_class, initiative, speed,
                 max_hit_points, curr_hit_points, temp_hit_points,
-                hit_dice, used_hit_dice, death_save_successes, death_save_failures,
-				exhaustion, concentration, inspiration, condition,
-				actions, bonus_actions, spell_slots, spell_slots_used,
+                death_save_successes, death_save_failures,
+				exhaustion, concentration, inspiration,
+				actions, bonus_actions, spell_slot_overrides, spell_slots_used,
                 spellcasting_ability, spell_save_dc, spell_attack_bonus,
 				age, height, weight, eyes, skin, hair, appearance, backstory,
-				personality
+				personality, initiative_bonus,
+				passive_perception, passive_insight, passive_investigation,
+				pact_slots, pact_slot_level, pact_slots_used,
+				spell_save_dc_item_bonus, spell_attack_item_bonus, unarmored_defense,
+				coin_weight, variant_encumbrance, attunement_limit, exhaustion_rules,
+				concentration_spell
             ) VALUES (
-                ?,?,?,?,?,?,?,?,?,?,?,
-                ?,?,?,?,?,?,?,?,?,?,?,?,
-				?,?,?,?,?,?,?,?,?,?,?,?
+                ?,?,?,?,?,?,?,?,?,?,
+                ?,?,?,?,?,?,?,?,?,?,
+				?,?,?,?,?,?,?,?,?,?,?,?,
+				?,?,?,?,?,?,?,?,?,?,?,?,?,?
 			) RETURNING id`
 		row := tx.QueryRowxContext(ctx, query,
-			c.Name, c.ClassLevels, c.Race, c.Alignment,
+			c.Name, c.Race, c.Alignment,
 			c.ProficiencyBonus, c.ArmorClass, c.Initiative, c.Speed,
 			c.MaxHitPoints, c.CurrHitPoints, c.TempHitPoints,
-			c.HitDice, c.UsedHitDice, c.DeathSaveSuccesses, c.DeathSaveFailures,
-			c.Exhaustion, c.Concentration, c.Inspiration, c.Condition,
-			c.Actions, c.BonusActions, c.SpellSlots, c.SpellSlotsUsed,
+			c.DeathSaveSuccesses, c.DeathSaveFailures,
+			c.Exhaustion, c.Concentration, c.Inspiration,
+			c.Actions, c.BonusActions, c.SpellSlotOverrides, c.SpellSlotsUsed,
 			c.SpellcastingAbility, c.SpellSaveDC, c.SpellAttackBonus,
 			c.Age, c.Height, c.Weight, c.Eyes, c.Skin, c.Hair, c.Appearance, c.Backstory,
-			c.Personality,
+			c.Personality, c.InitiativeBonus,
+			c.PassivePerception, c.PassiveInsight, c.PassiveInvestigation,
+			c.PactSlots, c.PactSlotLevel, c.PactSlotsUsed,
+			c.SpellSaveDCItemBonus, c.SpellAttackItemBonus, c.UnarmoredDefense,
+			c.CoinWeight, c.VariantEncumbrance, c.AttunementLimit, c.ExhaustionRules,
+			c.ConcentrationSpell,
 		)
 		if err := row.Scan(&newID); err != nil {
 			return err
@@ -65,6 +76,15 @@ func (r *DBCharacterRepository) create(ctx context.Context, agg *CharacterAggreg
 		if err := upsertOne(ctx, tx, walletTable, newID, agg.Wallet); err != nil {
 			return err
 		}
+		if err := replaceAll(ctx, tx, classTable, newID, agg.Classes); err != nil {
+			return err
+		}
+		if err := replaceAll(ctx, tx, resourceTable, newID, agg.Resources); err != nil {
+			return err
+		}
+		if err := replaceAll(ctx, tx, conditionTable, newID, agg.Conditions); err != nil {
+			return err
+		}
 		if err := replaceAll(ctx, tx, itemTable, newID, agg.Items); err != nil {
 			return err
 		}
@@ -80,10 +100,19 @@ func (r *DBCharacterRepository) create(ctx context.Context, agg *CharacterAggreg
 		if err := replaceAll(ctx, tx, noteTable, newID, agg.Notes); err != nil {
 			return err
 		}
+		if err := replaceAll(ctx, tx, macroTable, newID, agg.Macros); err != nil {
+			return err
+		}
 		skills := util.Map(agg.Skills, func(s models.CharacterSkillDetailTO) models.CharacterSkillTO { return s.ToCharacterSkillTO() })
 		if err := replaceAll(ctx, tx, skillTable, newID, skills); err != nil {
 			return err
 		}
+		if err := replaceAll(ctx, tx, sessionEventTable, newID, agg.Events); err != nil {
+			return err
+		}
+		if err := replaceAll(ctx, tx, rollTable, newID, agg.Rolls); err != nil {
+			return err
+		}
 		agg.Character.ID = newID
 		return nil
 	})
@@ -107,16 +136,22 @@ func (r *DBCharacterRepository) CreateEmpty(ctx context.Context, name string) (u
 		})
 	}
 	agg := CharacterAggregate{
-		Character:    &models.CharacterTO{Name: name},
+		Character:    &models.CharacterTO{Name: name, AttunementLimit: DefaultAttunementLimit},
 		Abilities:    &models.AbilitiesTO{},
 		SavingThrows: &models.SavingThrowsTO{},
 		Wallet:       &models.WalletTO{},
+		Classes:      []models.CharacterClassTO{},
+		Resources:    []models.ResourceTO{},
+		Conditions:   []models.ConditionTO{},
 		Items:        []models.ItemTO{},
 		Spells:       []models.SpellTO{},
 		Attacks:      []models.AttackTO{},
 		Skills:       skills,
 		Features:     []models.FeatureTO{},
 		Notes:        []models.NoteTO{},
+		Macros:       []models.MacroTO{},
+		Events:       []models.SessionEventTO{},
+		Rolls:        []models.RollTO{},
 	}
 	return r.create(ctx, &agg)
 }
@@ -126,7 +161,7 @@ func (r *DBCharacterRepository) GetByID(ctx context.Context, id uuid.UUID) (*Cha
 	if err := r.db.GetContext(ctx, &c, `SELECT * FROM character WHERE id = ?`, id); err != nil {
 		return nil, err
 	}
-	agg := &CharacterAggregate{Character: &c}
+	agg := &CharacterAggregate{Character: &c, session: uuid.New()}
 	if ab, err := getOne[models.AbilitiesTO](ctx, r.db, "abilities", id); err != nil {
 		return nil, err
 	} else {
@@ -142,6 +177,22 @@ func (r *DBCharacterRepository) GetByID(ctx context.Context, id uuid.UUID) (*Cha
 	} else {
 		agg.Wallet = w
 	}
+	missingAbilities, missingSavingThrows, missingWallet := agg.Abilities == nil, agg.SavingThrows == nil, agg.Wallet == nil
+	if classes, err := selectAll(ctx, r.db, classTable, id); err != nil {
+		return nil, err
+	} else {
+		agg.Classes = classes
+	}
+	if resources, err := selectAll(ctx, r.db, resourceTable, id); err != nil {
+		return nil, err
+	} else {
+		agg.Resources = resources
+	}
+	if conditions, err := selectAll(ctx, r.db, conditionTable, id); err != nil {
+		return nil, err
+	} else {
+		agg.Conditions = conditions
+	}
 	if items, err := selectAll(ctx, r.db, itemTable, id); err != nil {
 		return nil, err
 	} else {
@@ -167,12 +218,48 @@ func (r *DBCharacterRepository) GetByID(ctx context.Context, id uuid.UUID) (*Cha
 	} else {
 		agg.Notes = notes
 	}
+	if macros, err := selectAll(ctx, r.db, macroTable, id); err != nil {
+		return nil, err
+	} else {
+		agg.Macros = macros
+	}
 	if skills, err := r.ListSkillDetailsByCharacter(ctx, id); err != nil {
 		return nil, err
 	} else {
 		agg.Skills = skills
 	}
+	if events, err := selectAll(ctx, r.db, sessionEventTable, id); err != nil {
+		return nil, err
+	} else {
+		agg.Events = events
+	}
+	if rolls, err := selectAll(ctx, r.db, rollTable, id); err != nil {
+		return nil, err
+	} else {
+		agg.Rolls = rolls
+	}
+	// Owned rows can go missing without foreign keys (see dnc doctor). Load
+	// defaults instead and leave them out of the shadow so the next Update
+	// writes them.
+	if missingAbilities {
+		agg.Abilities = &models.AbilitiesTO{CharacterID: id}
+	}
+	if missingSavingThrows {
+		agg.SavingThrows = &models.SavingThrowsTO{CharacterID: id}
+	}
+	if missingWallet {
+		agg.Wallet = &models.WalletTO{CharacterID: id}
+	}
 	agg.shadow = agg.Clone()
+	if missingAbilities {
+		agg.shadow.Abilities = nil
+	}
+	if missingSavingThrows {
+		agg.shadow.SavingThrows = nil
+	}
+	if missingWallet {
+		agg.shadow.Wallet = nil
+	}
 	return agg, nil
 }
 
@@ -184,11 +271,14 @@ func (r *DBCharacterRepository) ListSummary(ctx context.Context) ([]models.Chara
 	return list, nil
 }
 
+// childTables lists every table holding rows owned by a character.
+var childTables = []string{
+	"wallet", "abilities", "saving_throws",
+	"character_class", "resource", "character_condition", "item", "spell", "attacks", "character_skill", "features", "notes",
+	"dice_macro", "session_event", "dice_roll",
+}
+
 func (r *DBCharacterRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	childTables := []string{
-		"wallet", "abilities", "saving_throws",
-		"item", "spell", "attacks", "character_skill", "features", "notes",
-	}
 	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
 		for _, name := range childTables {
 			if err := deleteByCharacter(ctx, tx, name, id); err != nil {
@@ -217,27 +307,38 @@ func (r *DBCharacterRepository) Update(ctx context.Context, agg *CharacterAggreg
 		ensureSpellSlots(c)
 		query := `
 			UPDATE character SET
-				name=?, class_levels=?, race=?, alignment=?,
+				name=?, race=?, alignment=?,
 				proficiency_bonus=?, armor_class=?, initiative=?, speed=?,
 				max_hit_points=?, curr_hit_points=?, temp_hit_points=?,
-				hit_dice=?, used_hit_dice=?, death_save_successes=?, death_save_failures=?,
-				exhaustion=?, concentration=?, inspiration=?, condition=?,
-				actions=?, bonus_actions=?, spell_slots=?, spell_slots_used=?,
+				death_save_successes=?, death_save_failures=?,
+				exhaustion=?, concentration=?, inspiration=?,
+				actions=?, bonus_actions=?, spell_slot_overrides=?, spell_slots_used=?,
 				spellcasting_ability=?, spell_save_dc=?, spell_attack_bonus=?,
 				age=?, height=?, weight=?, eyes=?, skin=?, hair=?, appearance=?,
-				backstory=?, personality=?, updated_at = current_timestamp
+				backstory=?, personality=?, initiative_bonus=?,
+				passive_perception=?, passive_insight=?, passive_investigation=?,
+				pact_slots=?, pact_slot_level=?, pact_slots_used=?,
+				spell_save_dc_item_bonus=?, spell_attack_item_bonus=?, unarmored_defense=?,
+				coin_weight=?, variant_encumbrance=?, attunement_limit=?, exhaustion_rules=?,
+				concentration_spell=?,
+				updated_at = current_timestamp
 			WHERE id=?
 		`
 		if _, err := tx.ExecContext(ctx, query,
-			c.Name, c.ClassLevels, c.Race, c.Alignment,
+			c.Name, c.Race, c.Alignment,
 			c.ProficiencyBonus, c.ArmorClass, c.Initiative, c.Speed,
 			c.MaxHitPoints, c.CurrHitPoints, c.TempHitPoints,
-			c.HitDice, c.UsedHitDice, c.DeathSaveSuccesses, c.DeathSaveFailures,
-			c.Exhaustion, c.Concentration, c.Inspiration, c.Condition,
-			c.Actions, c.BonusActions, c.SpellSlots, c.SpellSlotsUsed,
+			c.DeathSaveSuccesses, c.DeathSaveFailures,
+			c.Exhaustion, c.Concentration, c.Inspiration,
+			c.Actions, c.BonusActions, c.SpellSlotOverrides, c.SpellSlotsUsed,
 			c.SpellcastingAbility, c.SpellSaveDC, c.SpellAttackBonus,
 			c.Age, c.Height, c.Weight, c.Eyes, c.Skin, c.Hair, c.Appearance, c.Backstory,
-			c.Personality,
+			c.Personality, c.InitiativeBonus,
+			c.PassivePerception, c.PassiveInsight, c.PassiveInvestigation,
+			c.PactSlots, c.PactSlotLevel, c.PactSlotsUsed,
+			c.SpellSaveDCItemBonus, c.SpellAttackItemBonus, c.UnarmoredDefense,
+			c.CoinWeight, c.VariantEncumbrance, c.AttunementLimit, c.ExhaustionRules,
+			c.ConcentrationSpell,
 			c.ID,
 		); err != nil {
 			return err
@@ -267,6 +368,21 @@ func (r *DBCharacterRepository) Update(ctx context.Context, agg *CharacterAggreg
 		}
 
 		// Child (1:N) sections.
+		if shadow == nil || !reflect.DeepEqual(agg.Classes, shadow.Classes) {
+			if err := replaceAll(ctx, tx, classTable, id, agg.Classes); err != nil {
+				return err
+			}
+		}
+		if shadow == nil || !reflect.DeepEqual(agg.Resources, shadow.Resources) {
+			if err := replaceAll(ctx, tx, resourceTable, id, agg.Resources); err != nil {
+				return err
+			}
+		}
+		if shadow == nil || !reflect.DeepEqual(agg.Conditions, shadow.Conditions) {
+			if err := replaceAll(ctx, tx, conditionTable, id, agg.Conditions); err != nil {
+				return err
+			}
+		}
 		if shadow == nil || !reflect.DeepEqual(agg.Items, shadow.Items) {
 			if err := replaceAll(ctx, tx, itemTable, id, agg.Items); err != nil {
 				return err
@@ -292,11 +408,44 @@ func (r *DBCharacterRepository) Update(ctx context.Context, agg *CharacterAggreg
 				return err
 			}
 		}
+		if shadow == nil || !reflect.DeepEqual(agg.Macros, shadow.Macros) {
+			if err := replaceAll(ctx, tx, macroTable, id, agg.Macros); err != nil {
+				return err
+			}
+		}
 		if shadow == nil || !reflect.DeepEqual(skills, shadowSkills) {
 			if err := replaceAll(ctx, tx, skillTable, id, skills); err != nil {
 				return err
 			}
 		}
+
+		// Append-only sections.
+		if shadow == nil {
+			if err := replaceAll(ctx, tx, sessionEventTable, id, agg.Events); err != nil {
+				return err
+			}
+		} else if !reflect.DeepEqual(agg.Events, shadow.Events) {
+			logged := make(map[uuid.UUID]bool, len(shadow.Events))
+			for _, e := range shadow.Events {
+				logged[e.ID] = true
+			}
+			if err := appendNew(ctx, tx, sessionEventTable, id, agg.Events, func(e *models.SessionEventTO) bool { return logged[e.ID] }); err != nil {
+				return err
+			}
+		}
+		if shadow == nil {
+			if err := replaceAll(ctx, tx, rollTable, id, agg.Rolls); err != nil {
+				return err
+			}
+		} else if !reflect.DeepEqual(agg.Rolls, shadow.Rolls) {
+			rolled := make(map[uuid.UUID]bool, len(shadow.Rolls))
+			for _, r := range shadow.Rolls {
+				rolled[r.ID] = true
+			}
+			if err := appendNew(ctx, tx, rollTable, id, agg.Rolls, func(r *models.RollTO) bool { return rolled[r.ID] }); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 	if err == nil {
@@ -320,8 +469,8 @@ func (r *DBCharacterRepository) withTx(ctx context.Context, fn func(*sqlx.Tx) er
 }
 
 func ensureSpellSlots(c *models.CharacterTO) {
-	if c.SpellSlots == nil || len(c.SpellSlots) != 10 {
-		c.SpellSlots = make(models.IntList, 10)
+	if c.SpellSlotOverrides == nil || len(c.SpellSlotOverrides) != 10 {
+		c.SpellSlotOverrides = make(models.OptionalIntList, 10)
 	}
 	if c.SpellSlotsUsed == nil || len(c.SpellSlotsUsed) != 10 {
 		c.SpellSlotsUsed = make(models.IntList, 10)
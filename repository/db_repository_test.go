@@ -92,10 +92,12 @@ func diffIgnoringTimestampsOption() cmp.Option {
 	}, cmp.Ignore())
 }
 
-// childTablesUnderTest mirrors the cascade list in DBCharacterRepository.Delete.
+// childTablesUnderTest mirrors childTables so that adding a table without
+// extending the fixture fails loudly.
 var childTablesUnderTest = []string{
 	"wallet", "abilities", "saving_throws",
-	"item", "spell", "attacks", "character_skill", "features", "notes",
+	"character_class", "resource", "character_condition", "item", "spell", "attacks", "character_skill", "features", "notes",
+	"dice_macro", "session_event", "dice_roll",
 }
 
 // newTestRepo bootstraps a migrated temp DB and registers its teardown so a
@@ -380,3 +382,81 @@ func collectChildTimestamps(t *testing.T, h *sqlx.DB, charID uuid.UUID) map[stri
 	}
 	return out
 }
+
+// session events are append-only: logging a new one must persist it without
+// rewriting the events already stored.
+func TestUpdateAppendsSessionEvents(t *testing.T) {
+	repo, handle := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateEmpty(ctx, "Bobby")
+	if err != nil {
+		t.Fatalf("Could not create character: %s", err.Error())
+	}
+	testChar := TestCharacter(id)
+	if err := repo.Update(ctx, &testChar); err != nil {
+		t.Fatalf("Could not populate character: %s", err.Error())
+	}
+	loaded, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("Could not load character: %s", err.Error())
+	}
+	var before []time.Time
+	if err := handle.Select(&before, "SELECT created_at FROM session_event WHERE character_id=? ORDER BY created_at", id); err != nil {
+		t.Fatalf("query session_event: %s", err.Error())
+	}
+
+	loaded.LogEvent("heal", "5", "HP 50/100", "HP 55/100")
+	if err := repo.Update(ctx, loaded); err != nil {
+		t.Fatalf("Could not update character: %s", err.Error())
+	}
+	reloaded, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("Could not reload character: %s", err.Error())
+	}
+	if len(reloaded.Events) != len(testChar.Events)+1 {
+		t.Fatalf("expected %d events, got %d", len(testChar.Events)+1, len(reloaded.Events))
+	}
+	if last := reloaded.Events[len(reloaded.Events)-1]; last.Action != "heal" || last.After != "HP 55/100" {
+		t.Errorf("unexpected appended event: %+v", last)
+	}
+	for i, ts := range before {
+		if !reloaded.Events[i].CreatedAt.Equal(ts) {
+			t.Errorf("event %d created_at moved: before=%s after=%s", i, ts, reloaded.Events[i].CreatedAt)
+		}
+	}
+}
+
+// rolls are append-only like session events.
+func TestUpdateAppendsRolls(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateEmpty(ctx, "Bobby")
+	if err != nil {
+		t.Fatalf("Could not create character: %s", err.Error())
+	}
+	testChar := TestCharacter(id)
+	if err := repo.Update(ctx, &testChar); err != nil {
+		t.Fatalf("Could not populate character: %s", err.Error())
+	}
+	loaded, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("Could not load character: %s", err.Error())
+	}
+
+	loaded.LogRoll("4d6kh3", 15, "4d6kh3: 6 (1) 5 4")
+	if err := repo.Update(ctx, loaded); err != nil {
+		t.Fatalf("Could not update character: %s", err.Error())
+	}
+	reloaded, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("Could not reload character: %s", err.Error())
+	}
+	if len(reloaded.Rolls) != len(testChar.Rolls)+1 {
+		t.Fatalf("expected %d rolls, got %d", len(testChar.Rolls)+1, len(reloaded.Rolls))
+	}
+	if last := reloaded.Rolls[len(reloaded.Rolls)-1]; last.Expression != "4d6kh3" || last.Total != 15 || last.Detail != "4d6kh3: 6 (1) 5 4" {
+		t.Errorf("unexpected appended roll: %+v", last)
+	}
+}
@@ -68,6 +68,21 @@ func replaceAll[T any](ctx context.Context, tx *sqlx.Tx, spec childTable[T], cha
 	return nil
 }
 
+// appendNew inserts the items whose id is not yet known. Used for append-only
+// tables where rewriting the whole section on every change would be wasteful.
+func appendNew[T any](ctx context.Context, tx *sqlx.Tx, spec childTable[T], charID uuid.UUID, items []T, known func(*T) bool) error {
+	stmt := insertStmt(spec.name, spec.columns)
+	for i := range items {
+		if known(&items[i]) {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, stmt, spec.values(&items[i], charID)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func upsertOne[T any](ctx context.Context, tx *sqlx.Tx, spec ownedTable[T], charID uuid.UUID, item *T) error {
 	if item == nil {
 		return nil
@@ -101,15 +116,17 @@ func getOne[T any](ctx context.Context, q sqlx.QueryerContext, table string, cha
 }
 
 var itemTable = childTable[models.ItemTO]{
-	name:    "item",
-	columns: []string{"id", "character_id", "name", "is_equippable", "equipped", "attunement_slots", "quantity", "description", "created_at", "updated_at"},
+	name: "item",
+	columns: []string{"id", "character_id", "name", "is_equippable", "equipped", "attunement_slots", "attuned", "quantity", "description",
+		"weight", "armor_base_ac", "armor_dex_cap", "shield_bonus", "magic_ac_bonus", "created_at", "updated_at"},
 	orderBy: "name ASC",
 	values: func(it *models.ItemTO, charID uuid.UUID) []any {
 		if it.ID == uuid.Nil {
 			it.ID = uuid.New()
 		}
 		now := time.Now()
-		return []any{it.ID, charID, it.Name, it.IsEquippable, it.Equipped, it.AttunementSlots, it.Quantity, it.Description, nonZeroOr(it.CreatedAt, now), now}
+		return []any{it.ID, charID, it.Name, it.IsEquippable, it.Equipped, it.AttunementSlots, it.Attuned, it.Quantity, it.Description,
+			it.Weight, it.ArmorBaseAC, it.ArmorDexCap, it.ShieldBonus, it.MagicACBonus, nonZeroOr(it.CreatedAt, now), now}
 	},
 }
 
@@ -165,6 +182,45 @@ var noteTable = childTable[models.NoteTO]{
 	},
 }
 
+var classTable = childTable[models.CharacterClassTO]{
+	name:    "character_class",
+	columns: []string{"id", "character_id", "class_name", "subclass", "level", "hit_die", "hit_dice_used", "spellcasting", "spellcasting_ability", "created_at", "updated_at"},
+	orderBy: "level DESC, class_name ASC",
+	values: func(c *models.CharacterClassTO, charID uuid.UUID) []any {
+		if c.ID == uuid.Nil {
+			c.ID = uuid.New()
+		}
+		now := time.Now()
+		return []any{c.ID, charID, c.ClassName, c.Subclass, c.Level, c.HitDie, c.HitDiceUsed, c.Spellcasting, c.SpellcastingAbility, nonZeroOr(c.CreatedAt, now), now}
+	},
+}
+
+var resourceTable = childTable[models.ResourceTO]{
+	name:    "resource",
+	columns: []string{"id", "character_id", "name", "maximum", "max_formula", "max_class", "max_ability", "used", "recharge", "created_at", "updated_at"},
+	orderBy: "created_at ASC",
+	values: func(r *models.ResourceTO, charID uuid.UUID) []any {
+		if r.ID == uuid.Nil {
+			r.ID = uuid.New()
+		}
+		now := time.Now()
+		return []any{r.ID, charID, r.Name, r.Maximum, r.MaxFormula, r.MaxClass, r.MaxAbility, r.Used, r.Recharge, nonZeroOr(r.CreatedAt, now), now}
+	},
+}
+
+var conditionTable = childTable[models.ConditionTO]{
+	name:    "character_condition",
+	columns: []string{"id", "character_id", "condition", "name", "duration", "created_at", "updated_at"},
+	orderBy: "created_at ASC",
+	values: func(c *models.ConditionTO, charID uuid.UUID) []any {
+		if c.ID == uuid.Nil {
+			c.ID = uuid.New()
+		}
+		now := time.Now()
+		return []any{c.ID, charID, c.Condition, c.Name, c.Duration, nonZeroOr(c.CreatedAt, now), now}
+	},
+}
+
 var skillTable = childTable[models.CharacterSkillTO]{
 	name:    "character_skill",
 	columns: []string{"id", "character_id", "skill_id", "proficiency", "custom_modifier", "created_at", "updated_at"},
@@ -178,6 +234,43 @@ var skillTable = childTable[models.CharacterSkillTO]{
 	},
 }
 
+var sessionEventTable = childTable[models.SessionEventTO]{
+	name:    "session_event",
+	columns: []string{"id", "character_id", "session_id", "actor", "action", "args", "state_before", "state_after", "created_at"},
+	orderBy: "created_at ASC",
+	values: func(e *models.SessionEventTO, charID uuid.UUID) []any {
+		if e.ID == uuid.Nil {
+			e.ID = uuid.New()
+		}
+		return []any{e.ID, charID, e.SessionID, e.Actor, e.Action, e.Args, e.Before, e.After, nonZeroOr(e.CreatedAt, time.Now())}
+	},
+}
+
+var macroTable = childTable[models.MacroTO]{
+	name:    "dice_macro",
+	columns: []string{"id", "character_id", "name", "expression", "created_at", "updated_at"},
+	orderBy: "created_at ASC",
+	values: func(m *models.MacroTO, charID uuid.UUID) []any {
+		if m.ID == uuid.Nil {
+			m.ID = uuid.New()
+		}
+		now := time.Now()
+		return []any{m.ID, charID, m.Name, m.Expression, nonZeroOr(m.CreatedAt, now), now}
+	},
+}
+
+var rollTable = childTable[models.RollTO]{
+	name:    "dice_roll",
+	columns: []string{"id", "character_id", "expression", "total", "detail", "created_at"},
+	orderBy: "created_at ASC",
+	values: func(r *models.RollTO, charID uuid.UUID) []any {
+		if r.ID == uuid.Nil {
+			r.ID = uuid.New()
+		}
+		return []any{r.ID, charID, r.Expression, r.Total, r.Detail, nonZeroOr(r.CreatedAt, time.Now())}
+	},
+}
+
 var abilitiesTable = ownedTable[models.AbilitiesTO]{
 	name:    "abilities",
 	columns: []string{"character_id", "strength", "dexterity", "constitution", "intelligence", "wisdom", "charisma", "created_at", "updated_at"},
@@ -0,0 +1,448 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"hostettler.dev/dnc/models"
+)
+
+// Values derived from the rest of the sheet. Each can be overridden on the
+// character, an unset override means the derived value is used.
+
+// PassiveSkills are the skills with a passive score on the sheet.
+var PassiveSkills = []string{"Perception", "Insight", "Investigation"}
+
+func (c *CharacterAggregate) abilityScore(ability string) int {
+	if c.Abilities == nil {
+		return 10
+	}
+	return c.Abilities.ToScoreByName(ability)
+}
+
+func (c *CharacterAggregate) DerivedProficiencyBonus() int {
+	return models.ProficiencyBonusForLevel(c.TotalLevel())
+}
+
+func (c *CharacterAggregate) ProficiencyBonus() int {
+	return c.Character.ProficiencyBonus.Or(c.DerivedProficiencyBonus())
+}
+
+// DerivedInitiative is the Dexterity modifier plus other bonuses, e.g. from feats.
+func (c *CharacterAggregate) DerivedInitiative() int {
+	return models.ToModifier(c.abilityScore("dexterity"), models.NoProficiency, 0) + c.Character.InitiativeBonus
+}
+
+func (c *CharacterAggregate) Initiative() int {
+	return c.Character.Initiative.Or(c.DerivedInitiative())
+}
+
+// abilityModifier is 0 without an ability, e.g. no spellcasting ability.
+func (c *CharacterAggregate) abilityModifier(ability models.Ability) int {
+	if ability == models.NoAbility {
+		return 0
+	}
+	return models.ToModifier(c.abilityScore(ability.String()), models.NoProficiency, 0)
+}
+
+// DerivedSpellSaveDC is 8 + proficiency bonus + spellcasting modifier, plus
+// bonuses from items such as an arcane focus.
+func (c *CharacterAggregate) DerivedSpellSaveDC() int {
+	return 8 + c.ProficiencyBonus() + c.abilityModifier(models.Ability(c.Character.SpellcastingAbility)) +
+		c.Character.SpellSaveDCItemBonus
+}
+
+func (c *CharacterAggregate) SpellSaveDC() int {
+	return c.Character.SpellSaveDC.Or(c.DerivedSpellSaveDC())
+}
+
+func (c *CharacterAggregate) DerivedSpellAttackBonus() int {
+	return c.ProficiencyBonus() + c.abilityModifier(models.Ability(c.Character.SpellcastingAbility)) +
+		c.Character.SpellAttackItemBonus
+}
+
+func (c *CharacterAggregate) SpellAttackBonus() int {
+	return c.Character.SpellAttackBonus.Or(c.DerivedSpellAttackBonus())
+}
+
+// ClassSpellcastingAbility falls back to the spellcasting ability of the
+// character if the class has none of its own.
+func (c *CharacterAggregate) ClassSpellcastingAbility(cl *models.CharacterClassTO) models.Ability {
+	if cl.SpellcastingAbility != int(models.NoAbility) {
+		return models.Ability(cl.SpellcastingAbility)
+	}
+	return models.Ability(c.Character.SpellcastingAbility)
+}
+
+// ClassSpellcasting returns the spell save DC and attack bonus of a class.
+// Classes sharing the ability of the character use its values, overrides
+// included, others derive them from their own ability.
+func (c *CharacterAggregate) ClassSpellcasting(cl *models.CharacterClassTO) (saveDC, attackBonus int) {
+	ability := c.ClassSpellcastingAbility(cl)
+	if ability == models.Ability(c.Character.SpellcastingAbility) {
+		return c.SpellSaveDC(), c.SpellAttackBonus()
+	}
+	mod := c.abilityModifier(ability)
+	return 8 + c.ProficiencyBonus() + mod + c.Character.SpellSaveDCItemBonus,
+		c.ProficiencyBonus() + mod + c.Character.SpellAttackItemBonus
+}
+
+// ArmorClassPart is one line of the armor class breakdown.
+type ArmorClassPart struct {
+	Source string
+	Value  int
+}
+
+// ArmorClassBreakdown lists what the derived armor class is made of: the
+// equipped armor or the unarmored defense, Dexterity, a shield and magic
+// bonuses of equipped items.
+func (c *CharacterAggregate) ArmorClassBreakdown() []ArmorClassPart {
+	var armor, shield *models.ItemTO
+	equipped := []*models.ItemTO{}
+	for i := range c.Items {
+		it := &c.Items[i]
+		if it.IsEquippable == 0 || it.Equipped == 0 {
+			continue
+		}
+		equipped = append(equipped, it)
+		if it.ArmorBaseAC > 0 && (armor == nil || it.ArmorBaseAC > armor.ArmorBaseAC) {
+			armor = it
+		}
+		if it.ShieldBonus > 0 && (shield == nil || it.ShieldBonus > shield.ShieldBonus) {
+			shield = it
+		}
+	}
+
+	modifier := func(ability string) int {
+		return models.ToModifier(c.abilityScore(ability), models.NoProficiency, 0)
+	}
+	dex := modifier("dexterity")
+	var parts []ArmorClassPart
+	switch defense := models.UnarmoredDefense(c.Character.UnarmoredDefense); {
+	case armor != nil && armor.ArmorDexCap.Valid && armor.ArmorDexCap.Int == 0:
+		// Heavy armor ignores Dex, a penalty included.
+		parts = []ArmorClassPart{{armor.Name, armor.ArmorBaseAC}}
+	case armor != nil && armor.ArmorDexCap.Valid:
+		parts = []ArmorClassPart{{armor.Name, armor.ArmorBaseAC},
+			{fmt.Sprintf("Dex (max %d)", armor.ArmorDexCap.Int), min(dex, armor.ArmorDexCap.Int)}}
+	case armor != nil:
+		parts = []ArmorClassPart{{armor.Name, armor.ArmorBaseAC}, {"Dex", dex}}
+	case defense == models.BarbarianDefense:
+		parts = []ArmorClassPart{{"Unarmored Defense", 10}, {"Dex", dex}, {"Con", modifier("constitution")}}
+	case defense == models.MonkDefense && shield == nil:
+		parts = []ArmorClassPart{{"Unarmored Defense", 10}, {"Dex", dex}, {"Wis", modifier("wisdom")}}
+	case defense == models.NaturalArmor:
+		parts = []ArmorClassPart{{"Natural Armor", 13}, {"Dex", dex}}
+	default:
+		parts = []ArmorClassPart{{"Unarmored", 10}, {"Dex", dex}}
+	}
+	if shield != nil {
+		parts = append(parts, ArmorClassPart{shield.Name, shield.ShieldBonus})
+	}
+	for _, it := range equipped {
+		if it.MagicACBonus != 0 {
+			parts = append(parts, ArmorClassPart{it.Name + " (magic)", it.MagicACBonus})
+		}
+	}
+	return parts
+}
+
+func (c *CharacterAggregate) DerivedArmorClass() int {
+	ac := 0
+	for _, p := range c.ArmorClassBreakdown() {
+		ac += p.Value
+	}
+	return ac
+}
+
+func (c *CharacterAggregate) ArmorClass() int {
+	return c.Character.ArmorClass.Or(c.DerivedArmorClass())
+}
+
+// CoinsPerPound is the number of coins weighing a pound.
+const CoinsPerPound = 50
+
+// CoinWeight is the weight of the wallet in pounds, 0 unless coin weight
+// is enabled for the character.
+func (c *CharacterAggregate) CoinWeight() float64 {
+	if c.Character.CoinWeight == 0 || c.Wallet == nil {
+		return 0
+	}
+	w := c.Wallet
+	coins := w.Copper + w.Silver + w.Electrum + w.Gold + w.Platinum
+	return float64(coins) / CoinsPerPound
+}
+
+// CarriedWeight is the weight of all items and, if enabled, coins in pounds.
+func (c *CharacterAggregate) CarriedWeight() float64 {
+	total := c.CoinWeight()
+	for _, it := range c.Items {
+		total += it.Weight * float64(it.Quantity)
+	}
+	return total
+}
+
+// CarryingCapacity is 15 times the Strength score, in pounds.
+func (c *CharacterAggregate) CarryingCapacity() float64 {
+	return 15 * float64(c.abilityScore("strength"))
+}
+
+// EncumbranceThresholds returns the weights above which a character is
+// encumbered and heavily encumbered under the variant rules.
+func (c *CharacterAggregate) EncumbranceThresholds() (encumbered, heavily float64) {
+	str := float64(c.abilityScore("strength"))
+	return 5 * str, 10 * str
+}
+
+func (c *CharacterAggregate) Encumbrance() models.Encumbrance {
+	weight := c.CarriedWeight()
+	encumbered, heavily := c.EncumbranceThresholds()
+	switch {
+	case weight > c.CarryingCapacity():
+		return models.OverCapacity
+	case c.Character.VariantEncumbrance == 0:
+		return models.Unencumbered
+	case weight > heavily:
+		return models.HeavilyEncumbered
+	case weight > encumbered:
+		return models.Encumbered
+	}
+	return models.Unencumbered
+}
+
+// SpeedPenalty is 10 or 20 feet under the variant rules. Over capacity the
+// speed drops to 5 feet.
+func (c *CharacterAggregate) SpeedPenalty() int {
+	speed := c.Character.Speed
+	var penalty int
+	switch c.Encumbrance() {
+	case models.Encumbered:
+		penalty = 10
+	case models.HeavilyEncumbered:
+		penalty = 20
+	case models.OverCapacity:
+		penalty = speed - 5
+	}
+	return max(0, min(penalty, speed))
+}
+
+// ExhaustionSpeedPenalty applies to the speed left after encumbrance. Tiered
+// exhaustion halves it from level 2 and sets it to 0 from level 5.
+func (c *CharacterAggregate) ExhaustionSpeedPenalty() int {
+	speed := c.Character.Speed - c.SpeedPenalty()
+	level := c.Character.Exhaustion
+	var penalty int
+	switch {
+	case models.ExhaustionRules(c.Character.ExhaustionRules) == models.PenaltyExhaustion:
+		penalty = 5 * level
+	case level >= 5:
+		penalty = speed
+	case level >= 2:
+		penalty = speed - speed/2
+	}
+	return max(0, min(penalty, speed))
+}
+
+func (c *CharacterAggregate) Speed() int {
+	return c.Character.Speed - c.SpeedPenalty() - c.ExhaustionSpeedPenalty()
+}
+
+// ExhaustionPenalty is subtracted from d20 tests under the 2024 rules.
+func (c *CharacterAggregate) ExhaustionPenalty() int {
+	if models.ExhaustionRules(c.Character.ExhaustionRules) != models.PenaltyExhaustion {
+		return 0
+	}
+	return 2 * c.Character.Exhaustion
+}
+
+// HitPointMaximum is the maximum hit points after exhaustion, halved from
+// level 4 of tiered exhaustion.
+func (c *CharacterAggregate) HitPointMaximum() int {
+	if models.ExhaustionRules(c.Character.ExhaustionRules) == models.TieredExhaustion && c.Character.Exhaustion >= 4 {
+		return c.Character.MaxHitPoints / 2
+	}
+	return c.Character.MaxHitPoints
+}
+
+// ExhaustionEffects describes the active effects of exhaustion, e.g.
+// "Speed halved", cumulative for tiered exhaustion.
+func (c *CharacterAggregate) ExhaustionEffects() []string {
+	level := c.Character.Exhaustion
+	if level <= 0 {
+		return nil
+	}
+	var effects []string
+	if models.ExhaustionRules(c.Character.ExhaustionRules) == models.PenaltyExhaustion {
+		effects = []string{
+			fmt.Sprintf("-%d to d20 tests", c.ExhaustionPenalty()),
+			fmt.Sprintf("-%d ft speed", 5*level),
+		}
+	} else {
+		tiers := []string{
+			"Disadvantage on ability checks",
+			"Speed halved",
+			"Disadvantage on attack rolls and saving throws",
+			"Hit point maximum halved",
+			"Speed reduced to 0",
+		}
+		effects = tiers[:min(level, len(tiers))]
+	}
+	if level >= 6 {
+		effects = append(effects, "Death")
+	}
+	return effects
+}
+
+func (c *CharacterAggregate) baseSkillModifier(name string) int {
+	for _, s := range c.Skills {
+		if strings.EqualFold(s.SkillName, name) {
+			return models.ToModifier(c.abilityScore(s.SkillAbility), models.Proficiency(s.Proficiency), c.ProficiencyBonus()) +
+				s.CustomModifier
+		}
+	}
+	return 0
+}
+
+// SkillModifier returns the total modifier of the named skill including the
+// exhaustion penalty, 0 if the character has no such skill.
+func (c *CharacterAggregate) SkillModifier(name string) int {
+	return c.baseSkillModifier(name) - c.ExhaustionPenalty()
+}
+
+// SavingThrowModifier returns the total modifier of a saving throw including
+// the exhaustion penalty.
+func (c *CharacterAggregate) SavingThrowModifier(ability models.Ability) int {
+	prof := models.NoProficiency
+	if c.SavingThrows != nil {
+		prof = c.SavingThrows.ToProficiencyByAbility(ability)
+	}
+	return models.ToModifier(c.abilityScore(ability.String()), prof, c.ProficiencyBonus()) - c.ExhaustionPenalty()
+}
+
+// AttackBonus returns the bonus of an attack including the exhaustion
+// penalty.
+func (c *CharacterAggregate) AttackBonus(a *models.AttackTO) int {
+	return a.Bonus - c.ExhaustionPenalty()
+}
+
+// PassiveOverride returns the override of the passive score of one of
+// PassiveSkills, nil for other skills.
+func (c *CharacterAggregate) PassiveOverride(skill string) *models.OptionalInt {
+	switch strings.ToLower(skill) {
+	case "perception":
+		return &c.Character.PassivePerception
+	case "insight":
+		return &c.Character.PassiveInsight
+	case "investigation":
+		return &c.Character.PassiveInvestigation
+	}
+	return nil
+}
+
+// DerivedPassiveScore is not a d20 test, exhaustion does not apply.
+func (c *CharacterAggregate) DerivedPassiveScore(skill string) int {
+	return 10 + c.baseSkillModifier(skill)
+}
+
+func (c *CharacterAggregate) PassiveScore(skill string) int {
+	if o := c.PassiveOverride(skill); o != nil {
+		return o.Or(c.DerivedPassiveScore(skill))
+	}
+	return c.DerivedPassiveScore(skill)
+}
+
+func (c *CharacterAggregate) DerivedSpellSlots() []int {
+	return models.SpellSlotsForCasterLevel(models.CasterLevel(c.Classes))
+}
+
+// SpellSlots returns the maximum number of slots per spell level (0-9),
+// not counting pact magic.
+func (c *CharacterAggregate) SpellSlots() []int {
+	slots := c.DerivedSpellSlots()
+	for i, o := range c.Character.SpellSlotOverrides {
+		if i < len(slots) {
+			slots[i] = o.Or(slots[i])
+		}
+	}
+	return slots
+}
+
+func (c *CharacterAggregate) DerivedPactSlots() (slots, slotLevel int) {
+	level := 0
+	for _, cl := range c.Classes {
+		if models.SpellcastingType(cl.Spellcasting) == models.PactMagic {
+			level += cl.Level
+		}
+	}
+	return models.PactSlotsForLevel(level)
+}
+
+// PactSlots returns the number of pact magic slots and their spell level.
+func (c *CharacterAggregate) PactSlots() (slots, slotLevel int) {
+	slots, slotLevel = c.DerivedPactSlots()
+	return c.Character.PactSlots.Or(slots), c.Character.PactSlotLevel.Or(slotLevel)
+}
+
+// ClassLevel is the level in the named class, 0 if the character has none.
+func (c *CharacterAggregate) ClassLevel(name string) int {
+	level := 0
+	for _, cl := range c.Classes {
+		if strings.EqualFold(strings.TrimSpace(cl.ClassName), strings.TrimSpace(name)) {
+			level += cl.Level
+		}
+	}
+	return level
+}
+
+// ResourceMax is the maximum number of charges of a resource. Formulas add
+// the Maximum of the resource as a bonus and grant at least one charge.
+func (c *CharacterAggregate) ResourceMax(r *models.ResourceTO) int {
+	var base int
+	switch models.ResourceFormula(r.MaxFormula) {
+	case models.LevelMax:
+		base = c.TotalLevel()
+		if r.MaxClass != "" {
+			base = c.ClassLevel(r.MaxClass)
+		}
+	case models.AbilityModifierMax:
+		base = c.abilityModifier(models.Ability(r.MaxAbility))
+	case models.ProficiencyBonusMax:
+		base = c.ProficiencyBonus()
+	default:
+		return max(0, r.Maximum)
+	}
+	return max(1, base+r.Maximum)
+}
+
+func (c *CharacterAggregate) ResourceRemaining(r *models.ResourceTO) int {
+	return max(0, c.ResourceMax(r)-r.Used)
+}
+
+// RollModifier is an effect on a d20 roll and where it comes from. Bonus is
+// added to the roll, e.g. -2 per exhaustion level under the 2024 rules.
+type RollModifier struct {
+	Source string
+	Effect models.RollEffect
+	Bonus  int
+}
+
+// RollModifiers lists the effects of the conditions and exhaustion of the
+// character on a d20 roll. The ability is that of the check or saving throw.
+func (c *CharacterAggregate) RollModifiers(kind models.RollKind, ability models.Ability) []RollModifier {
+	var modifiers []RollModifier
+	for _, cd := range c.Conditions {
+		if effect := models.Condition(cd.Condition).RollEffect(kind, ability); effect != models.NoEffect {
+			modifiers = append(modifiers, RollModifier{Source: cd.Label(), Effect: effect})
+		}
+	}
+	level := c.Character.Exhaustion
+	source := fmt.Sprintf("Exhaustion %d", level)
+	switch {
+	case level <= 0:
+	case c.ExhaustionPenalty() > 0:
+		modifiers = append(modifiers, RollModifier{Source: source, Bonus: -c.ExhaustionPenalty()})
+	case kind == models.AbilityCheck || level >= 3:
+		modifiers = append(modifiers, RollModifier{Source: source, Effect: models.Disadvantage})
+	}
+	return modifiers
+}
@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/util"
+)
+
+func derivedTestAggregate() *CharacterAggregate {
+	agg := newTestAggregate()
+	agg.Abilities = &models.AbilitiesTO{Dexterity: 14, Wisdom: 12, Intelligence: 8}
+	agg.Classes = []models.CharacterClassTO{{ClassName: "Rogue", Level: 5}, {ClassName: "Cleric", Level: 1}}
+	agg.Skills = []models.CharacterSkillDetailTO{
+		{SkillName: "Perception", SkillAbility: "Wisdom", Proficiency: int(models.Expertise)},
+		{SkillName: "Insight", SkillAbility: "Wisdom"},
+		{SkillName: "Investigation", SkillAbility: "Intelligence", Proficiency: int(models.Proficient), CustomModifier: 1},
+	}
+	return agg
+}
+
+func TestDerivedValues(t *testing.T) {
+	agg := derivedTestAggregate()
+	agg.Character.InitiativeBonus = 5
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"proficiency bonus at level 6", agg.ProficiencyBonus(), 3},
+		{"initiative adds bonus to Dex", agg.Initiative(), 7},
+		{"passive Perception with expertise", agg.PassiveScore("Perception"), 17},
+		{"passive Insight", agg.PassiveScore("Insight"), 11},
+		{"passive Investigation with custom modifier", agg.PassiveScore("investigation"), 13},
+		{"unknown skill", agg.PassiveScore("Flying"), 10},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestOverridesReplaceDerivedValues(t *testing.T) {
+	agg := derivedTestAggregate()
+	agg.Character.ProficiencyBonus = models.Override(5)
+	agg.Character.Initiative = models.Override(0)
+	agg.Character.PassiveInsight = models.Override(20)
+
+	if got := agg.ProficiencyBonus(); got != 5 {
+		t.Errorf("ProficiencyBonus() = %d, want 5", got)
+	}
+	if got := agg.Initiative(); got != 0 {
+		t.Errorf("Initiative() = %d, want 0", got)
+	}
+	if got := agg.PassiveScore("Insight"); got != 20 {
+		t.Errorf("PassiveScore(Insight) = %d, want 20", got)
+	}
+	// The proficiency override feeds into skill based values.
+	if got := agg.PassiveScore("Perception"); got != 21 {
+		t.Errorf("PassiveScore(Perception) = %d, want 21", got)
+	}
+}
+
+func TestSpellSlotsFromClasses(t *testing.T) {
+	agg := newTestAggregate()
+	agg.Classes = []models.CharacterClassTO{
+		{ClassName: "Paladin", Level: 4, Spellcasting: int(models.HalfCaster)},
+		{ClassName: "Sorcerer", Level: 3, Spellcasting: int(models.FullCaster)},
+		{ClassName: "Warlock", Level: 3, Spellcasting: int(models.PactMagic)},
+	}
+	agg.Character.SpellSlotOverrides = models.OptionalIntList{{}, {}, models.Override(5)}
+
+	// Caster level 3 + 4/2 = 5, the override replaces only the 2nd level.
+	want := []int{0, 4, 5, 2, 0, 0, 0, 0, 0, 0}
+	if got := agg.SpellSlots(); !slices.Equal(got, want) {
+		t.Errorf("SpellSlots() = %v, want %v", got, want)
+	}
+	if slots, level := agg.PactSlots(); slots != 2 || level != 2 {
+		t.Errorf("PactSlots() = %d at level %d, want 2 at level 2", slots, level)
+	}
+
+	// Pact slots are used once the regular slots of the level run out.
+	agg.Character.SpellSlotsUsed = []int{0, 0, 5, 0, 0, 0, 0, 0, 0, 0}
+	if err := agg.CastSpell(2); err != nil {
+		t.Fatalf("CastSpell(2) failed: %s", err.Error())
+	}
+	if agg.Character.PactSlotsUsed != 1 {
+		t.Errorf("PactSlotsUsed = %d, want 1", agg.Character.PactSlotsUsed)
+	}
+	if err := agg.CastSpell(3); err != nil || agg.Character.SpellSlotsUsed[3] != 1 {
+		t.Errorf("CastSpell(3) should use a regular slot, got %v, used %v", err, agg.Character.SpellSlotsUsed)
+	}
+}
+
+func TestSpellcastingValues(t *testing.T) {
+	agg := derivedTestAggregate()
+	agg.Abilities.Charisma = 16
+	agg.Character.SpellcastingAbility = int(models.Charisma)
+	agg.Character.SpellSaveDCItemBonus = 1
+	agg.Classes = []models.CharacterClassTO{
+		{ClassName: "Sorcerer", Level: 5, Spellcasting: int(models.FullCaster)},
+		{ClassName: "Cleric", Level: 1, Spellcasting: int(models.FullCaster), SpellcastingAbility: int(models.Wisdom)},
+	}
+
+	if got := agg.SpellSaveDC(); got != 15 {
+		t.Errorf("SpellSaveDC() = %d, want 15", got)
+	}
+	if got := agg.SpellAttackBonus(); got != 6 {
+		t.Errorf("SpellAttackBonus() = %d, want 6", got)
+	}
+
+	// The Cleric uses Wisdom, the Sorcerer shares the overridden values.
+	agg.Character.SpellAttackBonus = models.Override(9)
+	if dc, attack := agg.ClassSpellcasting(&agg.Classes[1]); dc != 13 || attack != 4 {
+		t.Errorf("ClassSpellcasting(Cleric) = %d, %d, want 13, 4", dc, attack)
+	}
+	if dc, attack := agg.ClassSpellcasting(&agg.Classes[0]); dc != 15 || attack != 9 {
+		t.Errorf("ClassSpellcasting(Sorcerer) = %d, %d, want 15, 9", dc, attack)
+	}
+}
+
+func TestArmorClass(t *testing.T) {
+	chainShirt := models.ItemTO{Name: "Chain Shirt", IsEquippable: 1, Equipped: 1, ArmorBaseAC: 13, ArmorDexCap: models.Override(2)}
+	shield := models.ItemTO{Name: "Shield", IsEquippable: 1, Equipped: 1, ShieldBonus: 2}
+	ring := models.ItemTO{Name: "Ring of Protection", IsEquippable: 1, Equipped: 1, MagicACBonus: 1}
+	unequipped := models.ItemTO{Name: "Plate", IsEquippable: 1, ArmorBaseAC: 18, ArmorDexCap: models.Override(0)}
+
+	tests := []struct {
+		name    string
+		defense models.UnarmoredDefense
+		items   []models.ItemTO
+		want    int
+	}{
+		{"unarmored", models.StandardDefense, nil, 12},
+		{"medium armor caps Dex", models.StandardDefense, []models.ItemTO{chainShirt}, 15},
+		{"unequipped armor is ignored", models.StandardDefense, []models.ItemTO{unequipped}, 12},
+		{"shield and magic bonus", models.StandardDefense, []models.ItemTO{chainShirt, shield, ring}, 18},
+		{"barbarian adds Con", models.BarbarianDefense, []models.ItemTO{shield}, 17},
+		{"monk adds Wis", models.MonkDefense, nil, 13},
+		{"monk loses Wis with a shield", models.MonkDefense, []models.ItemTO{shield}, 14},
+		{"armor replaces unarmored defense", models.BarbarianDefense, []models.ItemTO{chainShirt}, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := derivedTestAggregate()
+			agg.Abilities.Constitution = 16
+			agg.Character.UnarmoredDefense = int(tt.defense)
+			agg.Items = tt.items
+			if got := agg.ArmorClass(); got != tt.want {
+				t.Errorf("ArmorClass() = %d, want %d, breakdown %v", got, tt.want, agg.ArmorClassBreakdown())
+			}
+		})
+	}
+
+	t.Run("heavy armor ignores a Dex penalty", func(t *testing.T) {
+		agg := derivedTestAggregate()
+		agg.Abilities.Dexterity = 8
+		plate := unequipped
+		plate.Equipped = 1
+		agg.Items = []models.ItemTO{plate}
+		if got := agg.ArmorClass(); got != 18 {
+			t.Errorf("ArmorClass() = %d, want 18, breakdown %v", got, agg.ArmorClassBreakdown())
+		}
+	})
+}
+
+func TestEncumbrance(t *testing.T) {
+	tests := []struct {
+		name        string
+		variant     bool
+		coins       bool
+		weight      float64
+		want        models.Encumbrance
+		wantPenalty int
+	}{
+		{"light load", false, false, 40, models.Unencumbered, 0},
+		{"standard rules ignore thresholds", false, false, 100, models.Unencumbered, 0},
+		{"variant encumbered", true, false, 60, models.Encumbered, 10},
+		{"variant heavily encumbered", true, false, 110, models.HeavilyEncumbered, 20},
+		{"coins tip the scale", true, true, 45, models.Encumbered, 10},
+		{"over capacity", false, false, 160, models.OverCapacity, 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := derivedTestAggregate()
+			agg.Abilities.Strength = 10
+			agg.Character.Speed = 30
+			agg.Character.VariantEncumbrance = util.B2i(tt.variant)
+			agg.Character.CoinWeight = util.B2i(tt.coins)
+			agg.Wallet = &models.WalletTO{Gold: 300}
+			agg.Items = []models.ItemTO{{Name: "Rations", Weight: tt.weight / 4, Quantity: 4}}
+
+			if got := agg.Encumbrance(); got != tt.want {
+				t.Errorf("Encumbrance() = %d, want %d (carrying %v lb)", got, tt.want, agg.CarriedWeight())
+			}
+			if got := agg.SpeedPenalty(); got != tt.wantPenalty {
+				t.Errorf("SpeedPenalty() = %d, want %d", got, tt.wantPenalty)
+			}
+		})
+	}
+}
+
+func TestResourceMax(t *testing.T) {
+	agg := derivedTestAggregate()
+	agg.Abilities.Charisma = 8
+	tests := []struct {
+		name     string
+		resource models.ResourceTO
+		want     int
+	}{
+		{"fixed", models.ResourceTO{Maximum: 4}, 4},
+		{"character level", models.ResourceTO{MaxFormula: int(models.LevelMax)}, 6},
+		{"class level", models.ResourceTO{MaxFormula: int(models.LevelMax), MaxClass: "rogue"}, 5},
+		{"missing class grants one", models.ResourceTO{MaxFormula: int(models.LevelMax), MaxClass: "Monk"}, 1},
+		{"ability modifier", models.ResourceTO{MaxFormula: int(models.AbilityModifierMax), MaxAbility: int(models.Dexterity)}, 2},
+		{"negative modifier grants one", models.ResourceTO{MaxFormula: int(models.AbilityModifierMax), MaxAbility: int(models.Charisma)}, 1},
+		{"proficiency bonus plus bonus", models.ResourceTO{MaxFormula: int(models.ProficiencyBonusMax), Maximum: 1}, 4},
+	}
+	for _, tt := range tests {
+		if got := agg.ResourceMax(&tt.resource); got != tt.want {
+			t.Errorf("%s: ResourceMax() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRollModifiers(t *testing.T) {
+	agg := newTestAggregate()
+	agg.Conditions = []models.ConditionTO{
+		{Condition: int(models.Poisoned)},
+		{Condition: int(models.Restrained)},
+		{Condition: int(models.CustomCondition), Name: "Hexed"},
+	}
+
+	tests := []struct {
+		kind    models.RollKind
+		ability models.Ability
+		want    []RollModifier
+	}{
+		{models.AttackRoll, models.NoAbility, []RollModifier{
+			{Source: "Poisoned", Effect: models.Disadvantage},
+			{Source: "Restrained", Effect: models.Disadvantage},
+		}},
+		{models.AbilityCheck, models.NoAbility, []RollModifier{{Source: "Poisoned", Effect: models.Disadvantage}}},
+		{models.SavingThrow, models.Dexterity, []RollModifier{{Source: "Restrained", Effect: models.Disadvantage}}},
+		{models.SavingThrow, models.Wisdom, nil},
+	}
+	for _, tt := range tests {
+		if got := agg.RollModifiers(tt.kind, tt.ability); !slices.Equal(got, tt.want) {
+			t.Errorf("RollModifiers(%d, %d) = %+v, want %+v", tt.kind, tt.ability, got, tt.want)
+		}
+	}
+}
+
+func TestExhaustion(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     models.ExhaustionRules
+		level     int
+		speed     int
+		skill     int
+		save      int
+		effects   int
+		attackDis bool
+		hpMax     int
+	}{
+		{"none", models.TieredExhaustion, 0, 30, 7, 2, 0, false, 41},
+		{"tiered speed halved", models.TieredExhaustion, 2, 15, 7, 2, 2, false, 41},
+		{"tiered attack disadvantage", models.TieredExhaustion, 3, 15, 7, 2, 3, true, 41},
+		{"tiered hit point maximum halved", models.TieredExhaustion, 4, 15, 7, 2, 4, true, 20},
+		{"tiered speed 0", models.TieredExhaustion, 5, 0, 7, 2, 5, true, 20},
+		{"penalty per level", models.PenaltyExhaustion, 3, 15, 1, -4, 2, false, 41},
+		{"penalty at death", models.PenaltyExhaustion, 6, 0, -5, -10, 3, false, 41},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := derivedTestAggregate()
+			agg.Character.Speed = 30
+			agg.Character.MaxHitPoints = 41
+			agg.Character.Exhaustion = tt.level
+			agg.Character.ExhaustionRules = int(tt.rules)
+
+			if got := agg.Speed(); got != tt.speed {
+				t.Errorf("Speed() = %d, want %d", got, tt.speed)
+			}
+			if got := agg.SkillModifier("Perception"); got != tt.skill {
+				t.Errorf("SkillModifier() = %d, want %d", got, tt.skill)
+			}
+			if got := agg.SavingThrowModifier(models.Dexterity); got != tt.save {
+				t.Errorf("SavingThrowModifier() = %d, want %d", got, tt.save)
+			}
+			if got, want := agg.AttackBonus(&models.AttackTO{Bonus: 5}), 5-agg.ExhaustionPenalty(); got != want {
+				t.Errorf("AttackBonus() = %d, want %d", got, want)
+			}
+			if got := agg.PassiveScore("Perception"); got != 17 {
+				t.Errorf("PassiveScore() = %d, want 17, exhaustion does not apply", got)
+			}
+			if got := agg.ExhaustionEffects(); len(got) != tt.effects {
+				t.Errorf("ExhaustionEffects() = %v, want %d effects", got, tt.effects)
+			}
+			attackDis := slices.ContainsFunc(agg.RollModifiers(models.AttackRoll, models.NoAbility), func(m RollModifier) bool {
+				return m.Effect == models.Disadvantage
+			})
+			if attackDis != tt.attackDis {
+				t.Errorf("disadvantage on attack rolls = %t, want %t", attackDis, tt.attackDis)
+			}
+			if got := agg.HitPointMaximum(); got != tt.hpMax {
+				t.Errorf("HitPointMaximum() = %d, want %d", got, tt.hpMax)
+			}
+		})
+	}
+}
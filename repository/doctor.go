@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"hostettler.dev/dnc/models"
+)
+
+// Issue is an inconsistency found by Diagnose. Without foreign keys the
+// database cannot prevent these itself, so they can appear after crashes,
+// manual edits or interrupted restores.
+type Issue struct {
+	Table       string
+	CharacterID uuid.UUID
+	Problem     string
+	repair      func(ctx context.Context, tx *sqlx.Tx) error
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s [%s]: %s", i.Table, i.CharacterID, i.Problem)
+}
+
+// Diagnose scans the database for orphaned rows, missing owned rows and
+// malformed spell slot lists. It does not modify anything.
+func (r *DBCharacterRepository) Diagnose(ctx context.Context) ([]Issue, error) {
+	checks := []func(context.Context) ([]Issue, error){
+		r.findOrphans,
+		r.findUnknownSkills,
+		r.findMissingOwned,
+		r.findMissingSkills,
+		r.findBadSpellSlots,
+	}
+	issues := []Issue{}
+	for _, check := range checks {
+		found, err := check(ctx)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+// Repair fixes the given issues in a single transaction.
+func (r *DBCharacterRepository) Repair(ctx context.Context, issues []Issue) error {
+	return r.withTx(ctx, func(tx *sqlx.Tx) error {
+		for _, issue := range issues {
+			if err := issue.repair(ctx, tx); err != nil {
+				return fmt.Errorf("repairing %s: %w", issue, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *DBCharacterRepository) findOrphans(ctx context.Context) ([]Issue, error) {
+	issues := []Issue{}
+	for _, table := range childTables {
+		var rows []struct {
+			CharacterID uuid.UUID `db:"character_id"`
+			Count       int       `db:"n"`
+		}
+		query := fmt.Sprintf(`
+			SELECT character_id, count(*) AS n FROM %s
+			WHERE character_id NOT IN (SELECT id FROM character)
+			GROUP BY character_id ORDER BY character_id`, table)
+		if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			issues = append(issues, Issue{
+				Table:       table,
+				CharacterID: row.CharacterID,
+				Problem:     fmt.Sprintf("%d row(s) belong to a character that does not exist", row.Count),
+				repair: func(ctx context.Context, tx *sqlx.Tx) error {
+					return deleteByCharacter(ctx, tx, table, row.CharacterID)
+				},
+			})
+		}
+	}
+	return issues, nil
+}
+
+func (r *DBCharacterRepository) findUnknownSkills(ctx context.Context) ([]Issue, error) {
+	var rows []struct {
+		ID          uuid.UUID `db:"id"`
+		CharacterID uuid.UUID `db:"character_id"`
+		SkillID     int       `db:"skill_id"`
+	}
+	query := `
+		SELECT id, character_id, skill_id FROM character_skill
+		WHERE skill_id NOT IN (SELECT id FROM skill_definition)
+		AND character_id IN (SELECT id FROM character)
+		ORDER BY character_id, skill_id`
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(rows))
+	for _, row := range rows {
+		issues = append(issues, Issue{
+			Table:       "character_skill",
+			CharacterID: row.CharacterID,
+			Problem:     fmt.Sprintf("skill row references unknown skill %d", row.SkillID),
+			repair: func(ctx context.Context, tx *sqlx.Tx) error {
+				_, err := tx.ExecContext(ctx, `DELETE FROM character_skill WHERE id=?`, row.ID)
+				return err
+			},
+		})
+	}
+	return issues, nil
+}
+
+func (r *DBCharacterRepository) findMissingOwned(ctx context.Context) ([]Issue, error) {
+	owned := []struct {
+		table  string
+		insert func(ctx context.Context, tx *sqlx.Tx, charID uuid.UUID) error
+	}{
+		{"abilities", func(ctx context.Context, tx *sqlx.Tx, charID uuid.UUID) error {
+			return upsertOne(ctx, tx, abilitiesTable, charID, &models.AbilitiesTO{})
+		}},
+		{"saving_throws", func(ctx context.Context, tx *sqlx.Tx, charID uuid.UUID) error {
+			return upsertOne(ctx, tx, savingThrowsTable, charID, &models.SavingThrowsTO{})
+		}},
+		{"wallet", func(ctx context.Context, tx *sqlx.Tx, charID uuid.UUID) error {
+			return upsertOne(ctx, tx, walletTable, charID, &models.WalletTO{})
+		}},
+	}
+	issues := []Issue{}
+	for _, o := range owned {
+		var ids []uuid.UUID
+		query := fmt.Sprintf(`
+			SELECT id FROM character
+			WHERE id NOT IN (SELECT character_id FROM %s)
+			ORDER BY id`, o.table)
+		if err := r.db.SelectContext(ctx, &ids, query); err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			issues = append(issues, Issue{
+				Table:       o.table,
+				CharacterID: id,
+				Problem:     "row is missing",
+				repair: func(ctx context.Context, tx *sqlx.Tx) error {
+					return o.insert(ctx, tx, id)
+				},
+			})
+		}
+	}
+	return issues, nil
+}
+
+func (r *DBCharacterRepository) findMissingSkills(ctx context.Context) ([]Issue, error) {
+	var rows []struct {
+		CharacterID uuid.UUID `db:"character_id"`
+		SkillID     int       `db:"skill_id"`
+		SkillName   string    `db:"skill_name"`
+	}
+	query := `
+		SELECT c.id AS character_id, sd.id AS skill_id, sd.name AS skill_name
+		FROM character c CROSS JOIN skill_definition sd
+		WHERE NOT EXISTS (
+			SELECT 1 FROM character_skill cs
+			WHERE cs.character_id = c.id AND cs.skill_id = sd.id
+		)
+		ORDER BY c.id, sd.id`
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+	stmt := insertStmt(skillTable.name, skillTable.columns)
+	issues := make([]Issue, 0, len(rows))
+	for _, row := range rows {
+		issues = append(issues, Issue{
+			Table:       "character_skill",
+			CharacterID: row.CharacterID,
+			Problem:     fmt.Sprintf("skill %q is missing", row.SkillName),
+			repair: func(ctx context.Context, tx *sqlx.Tx) error {
+				s := models.CharacterSkillTO{SkillID: row.SkillID}
+				_, err := tx.ExecContext(ctx, stmt, skillTable.values(&s, row.CharacterID)...)
+				return err
+			},
+		})
+	}
+	return issues, nil
+}
+
+func (r *DBCharacterRepository) findBadSpellSlots(ctx context.Context) ([]Issue, error) {
+	var ids []uuid.UUID
+	query := `
+		SELECT id FROM character
+		WHERE list_count(spell_slots_used) < len(spell_slots_used)
+		ORDER BY id`
+	if err := r.db.SelectContext(ctx, &ids, query); err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(ids))
+	for _, id := range ids {
+		issues = append(issues, Issue{
+			Table:       "character",
+			CharacterID: id,
+			Problem:     "used spell slots contain empty entries",
+			repair: func(ctx context.Context, tx *sqlx.Tx) error {
+				_, err := tx.ExecContext(ctx, `
+					UPDATE character SET
+						spell_slots_used = list_transform(spell_slots_used, x -> coalesce(x, 0))
+					WHERE id=?`, id)
+				return err
+			},
+		})
+	}
+
+	// Checked separately so that the comparison below never sees NULLs. The
+	// available slots depend on the class levels, so they are compared here
+	// rather than in SQL.
+	var chars []models.CharacterTO
+	query = `
+		SELECT id, spell_slot_overrides, spell_slots_used FROM character
+		WHERE list_count(spell_slots_used) = len(spell_slots_used)
+		ORDER BY id`
+	if err := r.db.SelectContext(ctx, &chars, query); err != nil {
+		return nil, err
+	}
+	for _, c := range chars {
+		classes, err := selectAll(ctx, r.db, classTable, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		agg := &CharacterAggregate{Character: &c, Classes: classes}
+		fixed, problem := checkUsedSlots(c.SpellSlotsUsed, agg.SpellSlots())
+		if problem == "" {
+			continue
+		}
+		issues = append(issues, Issue{
+			Table:       "character",
+			CharacterID: c.ID,
+			Problem:     problem,
+			repair: func(ctx context.Context, tx *sqlx.Tx) error {
+				_, err := tx.ExecContext(ctx, `UPDATE character SET spell_slots_used=? WHERE id=?`, fixed, c.ID)
+				return err
+			},
+		})
+	}
+	return issues, nil
+}
+
+// checkUsedSlots describes what is wrong with the used slots, if anything,
+// and returns them fixed. The column holds exactly 10 levels, the length is
+// checked in case a list is read from elsewhere. The list is resized first,
+// so a single repair writes the final list.
+func checkUsedSlots(used models.IntList, slots []int) (models.IntList, string) {
+	resized := resizeUsedSlots(used)
+	fixed, clamped := clampUsedSlots(resized, slots)
+	switch {
+	case len(used) != len(resized):
+		return fixed, fmt.Sprintf("used spell slots have %d levels instead of %d", len(used), len(resized))
+	case clamped:
+		return fixed, "used spell slots are negative or exceed the available slots"
+	}
+	return fixed, ""
+}
+
+// resizeUsedSlots pads or truncates the used slots to levels 0-9.
+func resizeUsedSlots(used models.IntList) models.IntList {
+	resized := make(models.IntList, 10)
+	copy(resized, used)
+	return resized
+}
+
+func clampUsedSlots(used models.IntList, slots []int) (models.IntList, bool) {
+	clamped := make(models.IntList, len(used))
+	changed := false
+	for i, u := range used {
+		limit := 0
+		if i < len(slots) {
+			limit = slots[i]
+		}
+		clamped[i] = max(0, min(u, limit))
+		changed = changed || clamped[i] != u
+	}
+	return clamped, changed
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"hostettler.dev/dnc/models"
+)
+
+func TestDoctorFindsAndRepairsIssues(t *testing.T) {
+	repo, handle := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateEmpty(ctx, "Bobby")
+	if err != nil {
+		t.Fatalf("Could not create a new character: %s", err.Error())
+	}
+	orphan, err := repo.CreateEmpty(ctx, "Orphan")
+	if err != nil {
+		t.Fatalf("Could not create a new character: %s", err.Error())
+	}
+
+	if issues, err := repo.Diagnose(ctx); err != nil {
+		t.Fatalf("Diagnose failed: %s", err.Error())
+	} else if len(issues) != 0 {
+		t.Fatalf("Expected a fresh DB to be clean, got %v", issues)
+	}
+
+	stmts := []struct {
+		query string
+		args  []any
+	}{
+		{`DELETE FROM character WHERE id=?`, []any{orphan}},
+		{`DELETE FROM wallet WHERE character_id=?`, []any{id}},
+		{`DELETE FROM character_skill WHERE character_id=? AND skill_id=1`, []any{id}},
+		{`UPDATE character SET spell_slots_used=[9,0,0,0,0,0,0,0,0,-1] WHERE id=?`, []any{id}},
+	}
+	for _, s := range stmts {
+		if _, err := handle.Exec(s.query, s.args...); err != nil {
+			t.Fatalf("Could not corrupt DB: %s", err.Error())
+		}
+	}
+
+	issues, err := repo.Diagnose(ctx)
+	if err != nil {
+		t.Fatalf("Diagnose failed: %s", err.Error())
+	}
+	found := map[string]bool{}
+	for _, issue := range issues {
+		found[issue.Table] = true
+	}
+	for _, table := range []string{"abilities", "saving_throws", "wallet", "character_skill", "character"} {
+		if !found[table] {
+			t.Errorf("Expected an issue for %s, got %v", table, issues)
+		}
+	}
+
+	if _, err := repo.GetByID(ctx, id); err != nil {
+		t.Errorf("Loading a character with a missing wallet failed: %s", err.Error())
+	}
+
+	if err := repo.Repair(ctx, issues); err != nil {
+		t.Fatalf("Repair failed: %s", err.Error())
+	}
+	if issues, err := repo.Diagnose(ctx); err != nil {
+		t.Fatalf("Diagnose failed: %s", err.Error())
+	} else if len(issues) != 0 {
+		t.Errorf("Expected no issues after repair, got %v", issues)
+	}
+	for _, table := range childTablesUnderTest {
+		if n := countRows(t, handle, table, orphan); n != 0 {
+			t.Errorf("%s: expected orphaned rows to be removed, got %d", table, n)
+		}
+	}
+	c, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("Could not fetch the character: %s", err.Error())
+	}
+	if c.Character.SpellSlotsUsed[0] != 0 || c.Character.SpellSlotsUsed[9] != 0 {
+		t.Errorf("Expected used slots to be clamped, got %v", c.Character.SpellSlotsUsed)
+	}
+}
+
+func TestCheckUsedSlots(t *testing.T) {
+	slots := []int{0, 4, 2, 0, 0, 0, 0, 0, 0, 0}
+	tests := []struct {
+		name        string
+		used        models.IntList
+		want        models.IntList
+		wantProblem string
+	}{
+		{"valid", models.IntList{0, 1, 2, 0, 0, 0, 0, 0, 0, 0}, models.IntList{0, 1, 2, 0, 0, 0, 0, 0, 0, 0}, ""},
+		{"clamped", models.IntList{0, 5, -1, 0, 0, 0, 0, 0, 0, 0}, models.IntList{0, 4, 0, 0, 0, 0, 0, 0, 0, 0}, "negative or exceed"},
+		{"padded", models.IntList{0, 1, 9}, models.IntList{0, 1, 2, 0, 0, 0, 0, 0, 0, 0}, "3 levels instead of 10"},
+		{"truncated", models.IntList{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3}, models.IntList{0, 1, 0, 0, 0, 0, 0, 0, 0, 0}, "12 levels instead of 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, problem := checkUsedSlots(tt.used, slots)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("checkUsedSlots() = %v, want %v", got, tt.want)
+			}
+			if tt.wantProblem == "" && problem != "" || !strings.Contains(problem, tt.wantProblem) {
+				t.Errorf("problem = %q, want one containing %q", problem, tt.wantProblem)
+			}
+		})
+	}
+}
+
+func TestMissingOwnedRowIsWrittenOnUpdate(t *testing.T) {
+	repo, handle := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateEmpty(ctx, "Bobby")
+	if err != nil {
+		t.Fatalf("Could not create a new character: %s", err.Error())
+	}
+	if _, err := handle.Exec(`DELETE FROM wallet WHERE character_id=?`, id); err != nil {
+		t.Fatalf("Could not delete wallet: %s", err.Error())
+	}
+	c, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("Could not fetch the character: %s", err.Error())
+	}
+	if c.Wallet == nil || c.Wallet.CharacterID != id {
+		t.Fatalf("Expected a default wallet, got %+v", c.Wallet)
+	}
+	if err := repo.Update(ctx, c); err != nil {
+		t.Fatalf("Could not update the character: %s", err.Error())
+	}
+	if n := countRows(t, handle, "wallet", id); n != 1 {
+		t.Errorf("Expected the wallet to be written back, got %d rows", n)
+	}
+}
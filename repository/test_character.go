@@ -2,6 +2,7 @@ package repository
 
 import (
 	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"hostettler.dev/dnc/models"
@@ -10,38 +11,40 @@ import (
 func TestCharacter(id uuid.UUID) CharacterAggregate {
 	c := CharacterAggregate{
 		Character: &models.CharacterTO{
-			ID:                  id,
-			Name:                "Bobby",
-			ClassLevels:         "Wizard 10",
-			Race:                "Gnome",
-			Alignment:           "Chaotic Evil",
-			ProficiencyBonus:    4,
-			ArmorClass:          17,
-			Initiative:          4,
-			Speed:               30,
-			MaxHitPoints:        100,
-			CurrHitPoints:       50,
-			TempHitPoints:       10,
-			HitDice:             "10d6",
-			UsedHitDice:         "5",
-			DeathSaveSuccesses:  2,
-			DeathSaveFailures:   2,
-			Actions:             "Kick",
-			BonusActions:        "Jump",
-			SpellSlots:          []int{0, 5, 5, 5, 4, 3, 3, 3, 2, 1},
-			SpellSlotsUsed:      []int{0, 3, 5, 4, 2, 2, 3, 1, 1, 0},
-			SpellcastingAbility: "Cha",
-			SpellSaveDC:         20,
-			SpellAttackBonus:    10,
-			Age:                 42,
-			Height:              "4'11",
-			Weight:              "200lbs",
-			Eyes:                "Green",
-			Skin:                "Pale",
-			Hair:                "Brown",
-			Appearance:          "Disheveled",
-			Backstory:           "Noone",
-			Personality:         "Crazy",
+			ID:                   id,
+			Name:                 "Bobby",
+			Race:                 "Gnome",
+			Alignment:            "Chaotic Evil",
+			ArmorClass:           models.Override(17),
+			CoinWeight:           1,
+			AttunementLimit:      DefaultAttunementLimit,
+			Initiative:           models.Override(4),
+			PassiveInsight:       models.Override(15),
+			Speed:                30,
+			MaxHitPoints:         100,
+			CurrHitPoints:        50,
+			TempHitPoints:        10,
+			DeathSaveSuccesses:   2,
+			DeathSaveFailures:    2,
+			ExhaustionRules:      int(models.PenaltyExhaustion),
+			Concentration:        1,
+			ConcentrationSpell:   "Abracadabra",
+			Actions:              "Kick",
+			BonusActions:         "Jump",
+			SpellSlotOverrides:   spellSlotOverrides(),
+			SpellSlotsUsed:       []int{0, 3, 5, 4, 2, 2, 3, 1, 1, 0},
+			SpellcastingAbility:  int(models.Charisma),
+			SpellSaveDCItemBonus: 1,
+			SpellAttackBonus:     models.Override(10),
+			Age:                  42,
+			Height:               "4'11",
+			Weight:               "200lbs",
+			Eyes:                 "Green",
+			Skin:                 "Pale",
+			Hair:                 "Brown",
+			Appearance:           "Disheveled",
+			Backstory:            "Noone",
+			Personality:          "Crazy",
 		},
 		Abilities: &models.AbilitiesTO{
 			CharacterID:  id,
@@ -69,6 +72,40 @@ func TestCharacter(id uuid.UUID) CharacterAggregate {
 			Gold:        40,
 			Platinum:    50,
 		},
+		Classes: []models.CharacterClassTO{{
+			ID:                  uuid.New(),
+			CharacterID:         id,
+			ClassName:           "Wizard",
+			Level:               10,
+			HitDie:              6,
+			HitDiceUsed:         5,
+			Spellcasting:        int(models.FullCaster),
+			SpellcastingAbility: int(models.Intelligence),
+		}},
+		Conditions: []models.ConditionTO{{
+			ID:          uuid.New(),
+			CharacterID: id,
+			Condition:   int(models.Poisoned),
+			Duration:    "1 hour",
+		}, {
+			ID:          uuid.New(),
+			CharacterID: id,
+			Name:        "Hexed",
+		}},
+		Resources: []models.ResourceTO{{
+			ID:          uuid.New(),
+			CharacterID: id,
+			Name:        "Arcane Recovery",
+			Maximum:     1,
+			Recharge:    int(models.LongRestRecharge),
+		}, {
+			ID:          uuid.New(),
+			CharacterID: id,
+			Name:        "Lucky",
+			MaxFormula:  int(models.ProficiencyBonusMax),
+			Used:        1,
+			Recharge:    int(models.LongRestRecharge),
+		}},
 		Spells: []models.SpellTO{{
 			ID:            uuid.New(),
 			CharacterID:   id,
@@ -91,8 +128,11 @@ func TestCharacter(id uuid.UUID) CharacterAggregate {
 			IsEquippable:    1,
 			Equipped:        1,
 			AttunementSlots: 3,
+			Attuned:         1,
 			Quantity:        1,
 			Description:     "Stick",
+			Weight:          3,
+			MagicACBonus:    1,
 		}},
 		Attacks: []models.AttackTO{{
 			ID:          uuid.New(),
@@ -381,8 +421,57 @@ func TestCharacter(id uuid.UUID) CharacterAggregate {
 				Note:        "Phasellus non orci sed sapien tristique convallis. Integer facilisis ligula sed erat hendrerit, vitae aliquam metus viverra. Phasellus non orci sed sapien tristique convallis.",
 			},
 		},
+		Macros: []models.MacroTO{{
+			ID:          uuid.New(),
+			CharacterID: id,
+			Name:        "smite",
+			Expression:  "2d8 + 1d8",
+		}},
+	}
+	session := uuid.New()
+	start := time.Date(2025, 3, 14, 19, 0, 0, 0, time.UTC)
+	c.Events = []models.SessionEventTO{
+		{
+			ID:          uuid.New(),
+			CharacterID: id,
+			SessionID:   session,
+			Actor:       "Bobby",
+			Action:      "dmg",
+			Args:        "60",
+			Before:      "HP 100/100 (+10)",
+			After:       "HP 50/100",
+			CreatedAt:   start,
+		},
+		{
+			ID:          uuid.New(),
+			CharacterID: id,
+			SessionID:   session,
+			Actor:       "Bobby",
+			Action:      "thp",
+			Args:        "10",
+			Before:      "HP 50/100",
+			After:       "HP 50/100 (+10)",
+			CreatedAt:   start.Add(5 * time.Minute),
+		},
+	}
+	c.Rolls = []models.RollTO{
+		{
+			ID:          uuid.New(),
+			CharacterID: id,
+			Expression:  "1d20 + 7",
+			Total:       23,
+			Detail:      "1d20: 16",
+			CreatedAt:   start.Add(2 * time.Minute),
+		},
 	}
 	// Ensure deterministic alphabetical order by Title so persistence test matches
 	sort.Slice(c.Notes, func(i, j int) bool { return c.Notes[i].Title < c.Notes[j].Title })
 	return c
 }
+
+// spellSlotOverrides overrides all but the 1st level slots.
+func spellSlotOverrides() models.OptionalIntList {
+	o := models.Overrides([]int{0, 5, 5, 5, 4, 3, 3, 3, 2, 1})
+	o[1] = models.OptionalInt{}
+	return o
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hostettler.dev/dnc/models"
+)
+
+var variable = regexp.MustCompile(`@([A-Za-z][A-Za-z_]*)`)
+
+// ExpandVariables replaces the variables in a dice expression with the
+// current values of the character, e.g. "1d8 + @str" with "1d8 + 3". Known
+// are the abilities (@str or @strength), @prof, @level, @spellatk, @spelldc,
+// @ac, @init and the skills, spaces left out (@sleightofhand or
+// @sleight_of_hand).
+func (c *CharacterAggregate) ExpandVariables(expr string) (string, error) {
+	var unknown string
+	expanded := variable.ReplaceAllStringFunc(expr, func(v string) string {
+		value, ok := c.variable(v[1:])
+		if !ok {
+			if unknown == "" {
+				unknown = v
+			}
+			return v
+		}
+		// Parenthesized so "1d8 - @str" stays correct for negative values.
+		if value < 0 {
+			return "(" + strconv.Itoa(value) + ")"
+		}
+		return strconv.Itoa(value)
+	})
+	if unknown != "" {
+		return "", fmt.Errorf("unknown variable %s", unknown)
+	}
+	return expanded, nil
+}
+
+func (c *CharacterAggregate) variable(name string) (int, bool) {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	switch name {
+	case "prof":
+		return c.ProficiencyBonus(), true
+	case "level":
+		return c.TotalLevel(), true
+	case "spellatk":
+		return c.SpellAttackBonus(), true
+	case "spelldc":
+		return c.SpellSaveDC(), true
+	case "ac":
+		return c.ArmorClass(), true
+	case "init":
+		return c.Initiative(), true
+	}
+	for _, s := range c.Skills {
+		if strings.ToLower(strings.ReplaceAll(s.SkillName, " ", "")) == name {
+			return c.SkillModifier(s.SkillName), true
+		}
+	}
+	if ability, ok := models.ParseAbility(name); ok {
+		return c.abilityModifier(ability), true
+	}
+	return 0, false
+}
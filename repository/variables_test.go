@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"hostettler.dev/dnc/models"
+)
+
+func TestExpandVariables(t *testing.T) {
+	agg := derivedTestAggregate()
+	agg.Skills = append(agg.Skills, models.CharacterSkillDetailTO{SkillName: "Sleight of Hand", SkillAbility: "Dexterity", Proficiency: int(models.Proficient)})
+
+	tests := []struct{ expr, want string }{
+		{"1d8 + @dex", "1d8 + 2"},
+		{"1d20 + @Dexterity + @prof", "1d20 + 2 + 3"},
+		{"1d6 - @int", "1d6 - (-1)"},
+		{"@level(1d6)", "6(1d6)"},
+		{"1d20 + @perception", "1d20 + 7"},
+		{"1d20 + @sleightofhand + @Sleight_of_Hand", "1d20 + 5 + 5"},
+		{"2d6 + 3", "2d6 + 3"},
+	}
+	for _, tt := range tests {
+		got, err := agg.ExpandVariables(tt.expr)
+		if err != nil || got != tt.want {
+			t.Errorf("ExpandVariables(%q) = %q, %v, want %q", tt.expr, got, err, tt.want)
+		}
+	}
+
+	if _, err := agg.ExpandVariables("1d8 + @foo + @bar"); err == nil || !strings.Contains(err.Error(), "@foo") {
+		t.Errorf("expected an error naming @foo, got %v", err)
+	}
+}
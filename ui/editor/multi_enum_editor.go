@@ -0,0 +1,112 @@
+package editor
+
+import (
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/ui/styles"
+	"hostettler.dev/dnc/util"
+)
+
+// multiEnumColumns is the number of options shown per line.
+const multiEnumColumns = 3
+
+// MultiEnumEditor selects any number of options. The selection is not a
+// single field, so it is read and written through get and set.
+type MultiEnumEditor[T IntLike] struct {
+	keymap   util.KeyMap
+	options  []styles.EnumMapping
+	label    string
+	get      func() []T
+	set      func([]T)
+	selected []bool
+	cursor   int
+	focus    bool
+}
+
+func NewMultiEnumEditor[T IntLike](keymap util.KeyMap, options []styles.EnumMapping, label string, get func() []T, set func([]T)) *MultiEnumEditor[T] {
+	e := &MultiEnumEditor[T]{
+		keymap:  keymap,
+		options: options,
+		label:   label,
+		get:     get,
+		set:     set,
+	}
+	e.Reload()
+	return e
+}
+
+func (e *MultiEnumEditor[T]) Reload() {
+	current := e.get()
+	e.selected = make([]bool, len(e.options))
+	for i, opt := range e.options {
+		e.selected[i] = slices.Contains(current, T(opt.Value))
+	}
+}
+
+func (e *MultiEnumEditor[T]) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	if len(e.options) == 0 {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, e.keymap.Left):
+			e.cursor = (e.cursor - 1 + len(e.options)) % len(e.options)
+		case key.Matches(msg, e.keymap.Right):
+			e.cursor = (e.cursor + 1) % len(e.options)
+		case key.Matches(msg, e.keymap.Select):
+			e.selected[e.cursor] = !e.selected[e.cursor]
+		case key.Matches(msg, e.keymap.Up):
+			cmd = command.FocusNextElementCmd(command.UpDirection)
+		case key.Matches(msg, e.keymap.Down):
+			cmd = command.FocusNextElementCmd(command.DownDirection)
+		}
+	}
+	return cmd
+}
+
+func (e *MultiEnumEditor[T]) View() string {
+	width := 0
+	for _, opt := range e.options {
+		width = max(width, len(opt.Label))
+	}
+	lines := []string{styles.RenderItem(e.focus, e.label+":")}
+	var line []string
+	for i, opt := range e.options {
+		cell := styles.PrettyBool(e.selected[i]) + " " + opt.Label
+		line = append(line, styles.RenderItem(e.focus && i == e.cursor, cell)+strings.Repeat(" ", width-len(opt.Label)))
+		if len(line) == multiEnumColumns || i == len(e.options)-1 {
+			lines = append(lines, strings.Join(line, "  "))
+			line = nil
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *MultiEnumEditor[T]) Save() tea.Cmd {
+	values := []T{}
+	for i, opt := range e.options {
+		if e.selected[i] {
+			values = append(values, T(opt.Value))
+		}
+	}
+	e.set(values)
+	return nil
+}
+
+func (e *MultiEnumEditor[T]) Focus() {
+	e.focus = true
+}
+
+func (e *MultiEnumEditor[T]) Blur() {
+	e.focus = false
+}
+
+func (e *MultiEnumEditor[T]) CapturesTextInput() bool {
+	return false
+}
@@ -3,11 +3,13 @@ package editor
 import (
 	"log/slog"
 	"strconv"
+	"strings"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/models"
 	"hostettler.dev/dnc/ui/styles"
 	"hostettler.dev/dnc/util"
 )
@@ -97,6 +99,37 @@ func NewIntEditor(keymap util.KeyMap, label string, value *int) *TextInputEditor
 	return newTextInputEditor(keymap, label, value, strconv.Atoi, strconv.Itoa)
 }
 
+func NewFloatEditor(keymap util.KeyMap, label string, value *float64) *TextInputEditor[float64] {
+	return newTextInputEditor(
+		keymap, label, value,
+		func(s string) (float64, error) { return strconv.ParseFloat(strings.TrimSpace(s), 64) },
+		func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) },
+	)
+}
+
+// NewOptionalIntEditor edits an override, an empty input unsets it.
+func NewOptionalIntEditor(keymap util.KeyMap, label string, value *models.OptionalInt) *TextInputEditor[models.OptionalInt] {
+	return newTextInputEditor(
+		keymap, label, value,
+		func(s string) (models.OptionalInt, error) {
+			if strings.TrimSpace(s) == "" {
+				return models.OptionalInt{}, nil
+			}
+			i, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return models.OptionalInt{}, err
+			}
+			return models.Override(i), nil
+		},
+		func(o models.OptionalInt) string {
+			if !o.Valid {
+				return ""
+			}
+			return strconv.Itoa(o.Int)
+		},
+	)
+}
+
 func NewStringEditor(keymap util.KeyMap, label string, value *string) *TextInputEditor[string] {
 	return newTextInputEditor(
 		keymap, label, value,
@@ -105,6 +138,18 @@ func NewStringEditor(keymap util.KeyMap, label string, value *string) *TextInput
 	)
 }
 
+// NewCheckedStringEditor discards the trimmed input unless check accepts it.
+func NewCheckedStringEditor(keymap util.KeyMap, label string, value *string, check func(string) error) *TextInputEditor[string] {
+	return newTextInputEditor(
+		keymap, label, value,
+		func(s string) (string, error) {
+			s = strings.TrimSpace(s)
+			return s, check(s)
+		},
+		func(s string) string { return s },
+	)
+}
+
 func (e *TextInputEditor[T]) CapturesTextInput() bool {
 	return true
 }
@@ -30,7 +30,8 @@ func (r *AppenderRow) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (r *AppenderRow) Trigger() tea.Cmd {
-	if r.onAppend == nil {
+	// a disabled append binding (read-only mode) disables the row as well
+	if r.onAppend == nil || !r.keymap.Append.Enabled() {
 		return nil
 	}
 	return r.onAppend()
@@ -15,7 +15,10 @@ type StructRow[T any] struct {
 	editors     []editor.ValueEditor
 	destructor  func() tea.Cmd
 	reader      func(*T) string
+	showAction  func(*T) tea.Cmd
 	cycleAction func(*T) tea.Cmd
+	rollAction  func(*T) tea.Cmd
+	editAction  func(*T) tea.Cmd
 	searchText  func(*T) string
 }
 
@@ -43,11 +46,29 @@ func (r *StructRow[T]) WithReader(reader func(*T) string) *StructRow[T] {
 	return r
 }
 
+// WithShowAction replaces the reader, e.g. to render it outside of Update.
+func (r *StructRow[T]) WithShowAction(action func(*T) tea.Cmd) *StructRow[T] {
+	r.showAction = action
+	return r
+}
+
 func (r *StructRow[T]) WithCycleAction(action func(*T) tea.Cmd) *StructRow[T] {
 	r.cycleAction = action
 	return r
 }
 
+// WithRollAction rolls the value, e.g. a skill check.
+func (r *StructRow[T]) WithRollAction(action func(*T) tea.Cmd) *StructRow[T] {
+	r.rollAction = action
+	return r
+}
+
+// WithEditAction replaces the inline editors, e.g. to open a dedicated screen.
+func (r *StructRow[T]) WithEditAction(action func(*T) tea.Cmd) *StructRow[T] {
+	r.editAction = action
+	return r
+}
+
 // makes the row searchable
 func (r *StructRow[T]) WithSearchText(searchText func(*T) string) *StructRow[T] {
 	r.searchText = searchText
@@ -62,14 +83,20 @@ func (r *StructRow[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
 		switch {
-		case key.Matches(msg, r.keymap.Edit):
+		case key.Matches(msg, r.keymap.Edit) && r.editAction != nil:
+			return r, r.editAction(r.value)
+		case key.Matches(msg, r.keymap.Edit) && len(r.editors) > 0:
 			return r, editor.EditValueCmd(r.editors)
 		case key.Matches(msg, r.keymap.Delete) && r.destructor != nil:
 			return r, command.LaunchConfirmationDialogueCmd(r.destructor)
+		case key.Matches(msg, r.keymap.Show) && r.showAction != nil:
+			return r, r.showAction(r.value)
 		case key.Matches(msg, r.keymap.Show) && r.reader != nil:
 			return r, command.LaunchReaderScreenCmd(r.reader(r.value))
 		case key.Matches(msg, r.keymap.Cycle) && r.cycleAction != nil:
 			return r, r.cycleAction(r.value)
+		case key.Matches(msg, r.keymap.Roll) && r.rollAction != nil:
+			return r, r.rollAction(r.value)
 		}
 	}
 	return r, nil
@@ -2,12 +2,16 @@ package quickaction
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/google/uuid"
 	"hostettler.dev/dicestats"
 	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/dice"
+	"hostettler.dev/dnc/models"
 	"hostettler.dev/dnc/repository"
 )
 
@@ -15,6 +19,8 @@ type ActionResult struct {
 	Cmd    tea.Cmd
 	ErrMsg string
 	Result string
+	// Suggest replaces the input after a result, e.g. to offer a follow-up roll.
+	Suggest string
 }
 
 type Action interface {
@@ -23,10 +29,50 @@ type Action interface {
 	Execute(agg *repository.CharacterAggregate, args string) ActionResult
 }
 
+// Access is how an action touches the character.
+type Access int
+
+const (
+	// Modifies actions are unavailable when the database is opened read-only.
+	Modifies Access = iota
+	// ReadsOnly actions never modify the character.
+	ReadsOnly
+	// LogsRolls actions only add to the roll log. They run without logging
+	// when the database is opened read-only.
+	LogsRolls
+)
+
+// AccessDeclarer is implemented by actions that do not modify the character.
+// Any other action is assumed to modify it, so forgetting to declare the
+// access blocks an action in read-only mode rather than letting it write.
+type AccessDeclarer interface {
+	Access() Access
+}
+
+func accessOf(a Action) Access {
+	if d, ok := a.(AccessDeclarer); ok {
+		return d.Access()
+	}
+	return Modifies
+}
+
+// Completion is a suggested argument of an action.
+type Completion struct {
+	Value string
+	Hint  string
+}
+
+// Completer is implemented by actions whose argument can be completed from
+// the character, e.g. with the names of macros.
+type Completer interface {
+	Complete(agg *repository.CharacterAggregate, prefix string) []Completion
+}
+
 type QuitAction struct{}
 
 func (a QuitAction) Name() string    { return "q" }
 func (a QuitAction) ArgHint() string { return "" }
+func (a QuitAction) Access() Access  { return ReadsOnly }
 
 func (a QuitAction) Execute(_ *repository.CharacterAggregate, _ string) ActionResult {
 	return ActionResult{Cmd: tea.Quit}
@@ -38,24 +84,144 @@ func (a LongRestAction) Name() string    { return "longrest" }
 func (a LongRestAction) ArgHint() string { return "" }
 
 func (a LongRestAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	before := restState(agg)
 	agg.LongRest()
+	agg.LogEvent(a.Name(), "", before, restState(agg))
 	return ActionResult{Cmd: command.WriteBackRequest}
 }
 
+// ShortRestAction spends hit dice, either given per size ("2d8 1d10") or as
+// a count spent from the largest dice first. Without arguments no dice are
+// spent, resources still recharge.
+type ShortRestAction struct {
+	// Roll rolls a die with the given number of sides, nil rolls randomly.
+	Roll dice.Roller
+}
+
+func (a ShortRestAction) Name() string    { return "shortrest" }
+func (a ShortRestAction) ArgHint() string { return "[n|2d8 1d10]" }
+
+func (a ShortRestAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	args = strings.TrimSpace(args)
+	hitDice, err := parseHitDice(agg, args)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	roll := a.Roll
+	if roll == nil {
+		roll = dice.Random
+	}
+	before := shortRestState(agg)
+	rolls, healed, err := agg.ShortRest(hitDice, roll)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	agg.LogEvent(a.Name(), args, before, shortRestState(agg))
+	if len(rolls) == 0 {
+		return ActionResult{Cmd: command.WriteBackRequest, Result: "no hit dice spent"}
+	}
+	parts := make([]string, len(rolls))
+	for i, r := range rolls {
+		parts[i] = fmt.Sprintf("d%d: %d", hitDice[i], r)
+	}
+	return ActionResult{
+		Cmd:    command.WriteBackRequest,
+		Result: fmt.Sprintf("%s, healed %d", strings.Join(parts, ", "), healed),
+	}
+}
+
+// parseHitDice turns the arguments of shortrest into the die sizes to spend.
+// A plain count takes the largest remaining dice first.
+func parseHitDice(agg *repository.CharacterAggregate, args string) ([]int, error) {
+	if args == "" {
+		return nil, nil
+	}
+	usage := fmt.Errorf("usage: shortrest [n|2d8 1d10]")
+	if n, err := strconv.Atoi(args); err == nil {
+		if n < 1 {
+			return nil, usage
+		}
+		var dice []int
+		for _, p := range agg.HitDice() {
+			for range min(n-len(dice), p.Remaining()) {
+				dice = append(dice, p.Die)
+			}
+		}
+		if len(dice) < n {
+			return nil, fmt.Errorf("not enough hit dice (%d left)", len(dice))
+		}
+		return dice, nil
+	}
+	var dice []int
+	for _, f := range strings.Fields(args) {
+		count, sides, found := strings.Cut(strings.ToLower(f), "d")
+		if !found {
+			return nil, usage
+		}
+		if count == "" {
+			count = "1"
+		}
+		n, err := strconv.Atoi(count)
+		die, dieErr := strconv.Atoi(sides)
+		if err != nil || dieErr != nil || n < 1 || die < 1 {
+			return nil, usage
+		}
+		for range n {
+			dice = append(dice, die)
+		}
+	}
+	return dice, nil
+}
+
+// CastAction uses a slot of the given level or casts a spell by name, at its
+// own level unless one is given. Concentration spells replace the current
+// concentration.
 type CastAction struct{}
 
 func (a CastAction) Name() string    { return "cast" }
-func (a CastAction) ArgHint() string { return "<level>" }
+func (a CastAction) ArgHint() string { return "<level>|<spell> [level]" }
 
 func (a CastAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
-	level, err := strconv.Atoi(strings.TrimSpace(args))
-	if err != nil || level < 1 || level > 9 {
-		return ActionResult{ErrMsg: "usage: cast <1-9>"}
+	args = strings.TrimSpace(args)
+	usage := "usage: cast <1-9>|<spell> [level]"
+	if level, err := strconv.Atoi(args); err == nil {
+		if level < 1 || level > 9 {
+			return ActionResult{ErrMsg: usage}
+		}
+		before := slotState(agg, level)
+		if err := agg.CastSpell(level); err != nil {
+			return ActionResult{ErrMsg: err.Error()}
+		}
+		agg.LogEvent(a.Name(), strconv.Itoa(level), before, slotState(agg, level))
+		return ActionResult{Cmd: command.WriteBackRequest}
 	}
-	if err := agg.CastSpell(level); err != nil {
+	name, level := args, -1
+	if i := strings.LastIndex(args, " "); i >= 0 {
+		if l, err := strconv.Atoi(args[i+1:]); err == nil {
+			name, level = strings.TrimSpace(args[:i]), l
+		}
+	}
+	if name == "" || level > 9 {
+		return ActionResult{ErrMsg: usage}
+	}
+	spell, err := agg.FindSpell(name)
+	if err != nil {
 		return ActionResult{ErrMsg: err.Error()}
 	}
-	return ActionResult{Cmd: command.WriteBackRequest}
+	if level < 0 {
+		level = spell.Level
+	}
+	before := castState(agg, level)
+	replaced, err := agg.Cast(spell, level)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	agg.LogEvent(a.Name(), fmt.Sprintf("%s %d", spell.Name, level), before, castState(agg, level))
+	res := ActionResult{Cmd: command.WriteBackRequest}
+	if replaced != "" {
+		res.Result = fmt.Sprintf("concentrating on %s, %s ended", spell.Name, replaced)
+	}
+	return res
 }
 
 type HealAction struct{}
@@ -68,10 +234,14 @@ func (a HealAction) Execute(agg *repository.CharacterAggregate, args string) Act
 	if err != nil || amount < 0 {
 		return ActionResult{ErrMsg: "usage: heal <amount>"}
 	}
+	before := hpState(agg)
 	agg.Heal(amount)
+	agg.LogEvent(a.Name(), strconv.Itoa(amount), before, hpState(agg))
 	return ActionResult{Cmd: command.WriteBackRequest}
 }
 
+// DmgAction takes damage. While concentrating it offers the Constitution
+// save to keep concentrating.
 type DmgAction struct{}
 
 func (a DmgAction) Name() string    { return "dmg" }
@@ -82,8 +252,304 @@ func (a DmgAction) Execute(agg *repository.CharacterAggregate, args string) Acti
 	if err != nil || amount < 0 {
 		return ActionResult{ErrMsg: "usage: dmg <amount>"}
 	}
+	concentrating, spell := agg.Concentrating(), agg.ConcentrationLabel()
+	state := func() string {
+		if concentrating {
+			return hpState(agg) + ", " + concentrationState(agg)
+		}
+		return hpState(agg)
+	}
+	before := state()
 	agg.TakeDamage(amount)
-	return ActionResult{Cmd: command.WriteBackRequest}
+	agg.LogEvent(a.Name(), strconv.Itoa(amount), before, state())
+	res := ActionResult{Cmd: command.WriteBackRequest}
+	switch {
+	case !concentrating || amount == 0:
+	case !agg.Concentrating():
+		res.Result = fmt.Sprintf("concentration on %s ended", spell)
+	default:
+		dc := repository.ConcentrationSaveDC(amount)
+		res.Result = fmt.Sprintf("concentration on %s: DC %d Con save, enter to roll", spell, dc)
+		res.Suggest = fmt.Sprintf("consave %d", dc)
+	}
+	return res
+}
+
+// ConSaveAction rolls the Constitution save to keep concentrating, failing
+// it ends the concentration.
+type ConSaveAction struct {
+	// Roll rolls a die with the given number of sides, nil rolls randomly.
+	Roll dice.Roller
+}
+
+func (a ConSaveAction) Name() string    { return "consave" }
+func (a ConSaveAction) ArgHint() string { return "<dc>" }
+
+func (a ConSaveAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	dc, err := strconv.Atoi(strings.TrimSpace(args))
+	if err != nil || dc < 1 {
+		return ActionResult{ErrMsg: "usage: consave <dc>"}
+	}
+	if !agg.Concentrating() {
+		return ActionResult{ErrMsg: "not concentrating"}
+	}
+	roll := a.Roll
+	if roll == nil {
+		roll = dice.Random
+	}
+	before := concentrationState(agg)
+	spell := agg.ConcentrationLabel()
+	natural, _, effect := rollD20(roll, agg.RollModifiers(models.SavingThrow, models.Constitution))
+	total := natural + agg.SavingThrowModifier(models.Constitution)
+	held := effect != models.AutoFail && total >= dc
+	if !held {
+		agg.EndConcentration()
+	}
+	agg.LogEvent(a.Name(), strconv.Itoa(dc), before, concentrationState(agg))
+	outcome := "holds"
+	if !held {
+		outcome = "ends"
+	}
+	return ActionResult{
+		Cmd:    command.WriteBackRequest,
+		Result: fmt.Sprintf("Con save %d (d20: %d) vs DC %d, concentration on %s %s", total, natural, dc, spell, outcome),
+	}
+}
+
+// CheckAction rolls a skill check, optionally with advantage or
+// disadvantage on top of the conditions of the character.
+type CheckAction struct {
+	// Roll rolls a die with the given number of sides, nil rolls randomly.
+	Roll dice.Roller
+}
+
+func (a CheckAction) Name() string    { return "check" }
+func (a CheckAction) ArgHint() string { return "<skill> [adv|dis]" }
+func (a CheckAction) Access() Access  { return LogsRolls }
+
+func (a CheckAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	name, asked := parseAdvantage(args)
+	if name == "" {
+		return ActionResult{ErrMsg: "usage: check <skill> [adv|dis]"}
+	}
+	skill, err := agg.FindSkill(name)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	ability, _ := models.ParseAbility(skill.SkillAbility)
+	test := rollD20Test(agg, a.Roll, models.AbilityCheck, ability, agg.SkillModifier(skill.SkillName), asked)
+	return test.result(agg, skill.SkillName+" check")
+}
+
+// SaveAction rolls a saving throw like CheckAction.
+type SaveAction struct {
+	// Roll rolls a die with the given number of sides, nil rolls randomly.
+	Roll dice.Roller
+}
+
+func (a SaveAction) Name() string    { return "save" }
+func (a SaveAction) ArgHint() string { return "<ability> [adv|dis]" }
+func (a SaveAction) Access() Access  { return LogsRolls }
+
+func (a SaveAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	name, asked := parseAdvantage(args)
+	ability, ok := models.ParseAbility(name)
+	if !ok {
+		return ActionResult{ErrMsg: "usage: save <ability> [adv|dis]"}
+	}
+	test := rollD20Test(agg, a.Roll, models.SavingThrow, ability, agg.SavingThrowModifier(ability), asked)
+	return test.result(agg, ability.Short()+" save")
+}
+
+// AttackAction rolls an attack and its damage. A natural 20 is a critical
+// hit rolling the damage dice twice, a natural 1 always misses.
+type AttackAction struct {
+	// Roll rolls a die with the given number of sides, nil rolls randomly.
+	Roll dice.Roller
+}
+
+func (a AttackAction) Name() string    { return "attack" }
+func (a AttackAction) ArgHint() string { return "<name> [vs AC] [adv|dis]" }
+func (a AttackAction) Access() Access  { return LogsRolls }
+
+func (a AttackAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	name, asked := parseAdvantage(args)
+	name, ac := parseTargetAC(name)
+	if name == "" {
+		return ActionResult{ErrMsg: "usage: attack <name> [vs AC] [adv|dis]"}
+	}
+	attack, err := agg.FindAttack(name)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	return a.rollAttack(agg, attack, ac, asked)
+}
+
+// rollAttack rolls the attack against the AC, 0 for none, and its damage on
+// a hit.
+func (a AttackAction) rollAttack(agg *repository.CharacterAggregate, attack *models.AttackTO, ac int, asked models.RollEffect) ActionResult {
+	var damage dice.Expr
+	if attack.Damage != "" {
+		expr, err := agg.ExpandVariables(attack.Damage)
+		if err == nil {
+			damage, err = dice.Parse(expr)
+		}
+		if err != nil {
+			return ActionResult{ErrMsg: fmt.Sprintf("damage of %s: %s", attack.Name, err)}
+		}
+	}
+	roll := a.Roll
+	if roll == nil {
+		roll = dice.Random
+	}
+
+	test := rollD20Test(agg, roll, models.AttackRoll, models.NoAbility, agg.AttackBonus(attack), asked)
+	critical := test.natural == 20
+	hit := critical || (test.natural != 1 && (ac == 0 || test.total() >= ac))
+	agg.LogRoll(attack.Name+" attack", test.total(), test.detail())
+	line := fmt.Sprintf("%s %d to hit (%s)", attack.Name, test.total(), test.detail())
+	if ac > 0 {
+		line += fmt.Sprintf(" vs AC %d", ac)
+	}
+	switch {
+	case critical:
+		line += ", critical hit"
+	case test.natural == 1:
+		line += ", natural 1 misses"
+	case ac > 0 && hit:
+		line += ", hit"
+	case ac > 0:
+		line += ", miss"
+	}
+	lines := []string{line}
+
+	if hit && attack.Damage != "" {
+		rollDamage := damage.Roll
+		if critical {
+			rollDamage = damage.RollCritical
+		}
+		res, err := rollDamage(roll)
+		if err != nil {
+			return ActionResult{ErrMsg: fmt.Sprintf("damage of %s: %s", attack.Name, err)}
+		}
+		agg.LogRoll(attack.Name+" damage", res.Total, res.Detail())
+		line := strings.TrimSpace(fmt.Sprintf("%d %s", res.Total, attack.DamageType)) + " damage"
+		if detail := res.Detail(); detail != "" {
+			line += " (" + detail + ")"
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, test.notes()...)
+	return ActionResult{Cmd: command.WriteBackRequest, Result: strings.Join(lines, "\n")}
+}
+
+// parseTargetAC splits a trailing "vs <AC>" off the arguments, the AC is 0
+// without a target.
+func parseTargetAC(args string) (string, int) {
+	fields := strings.Fields(args)
+	if n := len(fields); n >= 2 && strings.EqualFold(fields[n-2], "vs") {
+		if ac, err := strconv.Atoi(fields[n-1]); err == nil && ac > 0 {
+			return strings.Join(fields[:n-2], " "), ac
+		}
+	}
+	return strings.Join(fields, " "), 0
+}
+
+// parseAdvantage splits a trailing adv or dis off the arguments.
+func parseAdvantage(args string) (string, models.RollEffect) {
+	fields := strings.Fields(args)
+	if n := len(fields); n > 0 {
+		switch strings.ToLower(fields[n-1]) {
+		case "adv":
+			return strings.Join(fields[:n-1], " "), models.Advantage
+		case "dis":
+			return strings.Join(fields[:n-1], " "), models.Disadvantage
+		}
+	}
+	return strings.Join(fields, " "), models.NoEffect
+}
+
+// d20Test is a rolled ability check or saving throw.
+type d20Test struct {
+	dice     dice.Group
+	natural  int
+	modifier int
+	effect   models.RollEffect
+	// sources are the conditions and exhaustion affecting the roll.
+	sources []repository.RollModifier
+}
+
+// rollD20Test rolls a d20 and adds the modifier. Advantage or disadvantage
+// asked for combines with the effects of the conditions and exhaustion.
+func rollD20Test(agg *repository.CharacterAggregate, roll dice.Roller, kind models.RollKind, ability models.Ability, modifier int, asked models.RollEffect) d20Test {
+	if roll == nil {
+		roll = dice.Random
+	}
+	sources := agg.RollModifiers(kind, ability)
+	modifiers := sources
+	if asked != models.NoEffect {
+		modifiers = append(slices.Clone(sources), repository.RollModifier{Source: asked.String(), Effect: asked})
+	}
+	natural, group, effect := rollD20(roll, modifiers)
+	return d20Test{dice: group, natural: natural, modifier: modifier, effect: effect, sources: sources}
+}
+
+func (t d20Test) total() int {
+	return t.natural + t.modifier
+}
+
+// detail shows the dice and the modifier, e.g. "2d20kh1: 12 (7), +3".
+func (t d20Test) detail() string {
+	return fmt.Sprintf("%s, %+d", t.dice, t.modifier)
+}
+
+// notes lists the sources affecting the roll, e.g. "Poisoned: disadvantage".
+func (t d20Test) notes() []string {
+	notes := make([]string, len(t.sources))
+	for i, m := range t.sources {
+		notes[i] = m.Source + ": " + rollEffect(m)
+	}
+	return notes
+}
+
+// result shows the total with the dice and modifier, e.g.
+// "Stealth check 15 (2d20kh1: 12 (7), +3)", followed by the notes. The roll
+// is added to the roll log.
+func (t d20Test) result(agg *repository.CharacterAggregate, label string) ActionResult {
+	agg.LogRoll(label, t.total(), t.detail())
+	line := fmt.Sprintf("%s %d (%s)", label, t.total(), t.detail())
+	if t.effect == models.AutoFail {
+		line += ", " + t.effect.String()
+	}
+	lines := append([]string{line}, t.notes()...)
+	return ActionResult{Cmd: command.WriteBackRequest, Result: strings.Join(lines, "\n")}
+}
+
+// rollD20 rolls a d20, twice with advantage or disadvantage unless both
+// apply. It returns the kept roll, the dice rolled and the resulting effect.
+func rollD20(roll dice.Roller, modifiers []repository.RollModifier) (int, dice.Group, models.RollEffect) {
+	var adv, dis bool
+	for _, m := range modifiers {
+		switch m.Effect {
+		case models.AutoFail:
+			r := roll(20)
+			return r, dice.Group{Notation: "1d20", Rolls: []int{r}, Dropped: []bool{false}}, models.AutoFail
+		case models.Advantage:
+			adv = true
+		case models.Disadvantage:
+			dis = true
+		}
+	}
+	first := roll(20)
+	if adv == dis {
+		return first, dice.Group{Notation: "1d20", Rolls: []int{first}, Dropped: []bool{false}}, models.NoEffect
+	}
+	second := roll(20)
+	kept, notation, effect := max(first, second), "2d20kh1", models.Advantage
+	if dis {
+		kept, notation, effect = min(first, second), "2d20kl1", models.Disadvantage
+	}
+	return kept, dice.Group{Notation: notation, Rolls: []int{first, second}, Dropped: []bool{first != kept, first == kept}}, effect
 }
 
 type TempHPAction struct{}
@@ -96,21 +562,161 @@ func (a TempHPAction) Execute(agg *repository.CharacterAggregate, args string) A
 	if err != nil || amount < 0 {
 		return ActionResult{ErrMsg: "usage: thp <amount>"}
 	}
+	before := hpState(agg)
 	agg.SetTempHP(amount)
+	agg.LogEvent(a.Name(), strconv.Itoa(amount), before, hpState(agg))
+	return ActionResult{Cmd: command.WriteBackRequest}
+}
+
+type AttuneAction struct{}
+
+func (a AttuneAction) Name() string    { return "attune" }
+func (a AttuneAction) ArgHint() string { return "<item>" }
+
+func (a AttuneAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	if strings.TrimSpace(args) == "" {
+		return ActionResult{ErrMsg: "usage: attune <item>"}
+	}
+	item, err := agg.FindItem(args)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	before := attunementState(agg)
+	if err := agg.Attune(item); err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	agg.LogEvent(a.Name(), item.Name, before, attunementState(agg))
 	return ActionResult{Cmd: command.WriteBackRequest}
 }
 
+type LogAction struct{}
+
+func (a LogAction) Name() string    { return "log" }
+func (a LogAction) ArgHint() string { return "" }
+func (a LogAction) Access() Access  { return ReadsOnly }
+
+func (a LogAction) Execute(_ *repository.CharacterAggregate, _ string) ActionResult {
+	return ActionResult{Cmd: command.SwitchScreenCmd(command.SessionLogScreenIndex)}
+}
+
+// RollAction rolls a dice expression and records it in the roll log.
+type RollAction struct {
+	// Roll rolls a die with the given number of sides, nil rolls randomly.
+	Roll dice.Roller
+}
+
+func (a RollAction) Name() string    { return "roll" }
+func (a RollAction) ArgHint() string { return "<expression|macro>" }
+func (a RollAction) Access() Access  { return LogsRolls }
+
+func (a RollAction) Complete(agg *repository.CharacterAggregate, prefix string) []Completion {
+	return completeMacros(agg, prefix)
+}
+
+func (a RollAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	args = strings.TrimSpace(args)
+	if args == "" {
+		return ActionResult{ErrMsg: "usage: roll <expression|macro>"}
+	}
+	roll := a.Roll
+	if roll == nil {
+		roll = dice.Random
+	}
+	expr, label, err := resolveExpression(agg, args)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	res, err := dice.Roll(expr, roll)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	agg.LogRoll(label, res.Total, res.Detail())
+	result := strconv.Itoa(res.Total)
+	if detail := res.Detail(); detail != "" {
+		result += " (" + detail + ")"
+	}
+	return ActionResult{Cmd: command.WriteBackRequest, Result: withRollNote(agg, expr, result)}
+}
+
+// resolveExpression replaces the name of a macro with its expression and
+// expands the variables in it. The label names the macro for the roll log.
+func resolveExpression(agg *repository.CharacterAggregate, args string) (expr, label string, err error) {
+	expr = args
+	macro, isMacro := agg.Macro(args)
+	if isMacro {
+		expr = macro.Expression
+	}
+	if expr, err = agg.ExpandVariables(expr); err != nil {
+		return "", "", err
+	}
+	if isMacro {
+		return expr, macro.Name + ": " + expr, nil
+	}
+	return expr, expr, nil
+}
+
+// CheckMacroName rejects what CharacterAggregate.CheckMacroName does and
+// names read as a dice expression, e.g. "d20" or "2d6", which the macro
+// would shadow in resolveExpression.
+func CheckMacroName(agg *repository.CharacterAggregate, id uuid.UUID, name string) error {
+	if err := agg.CheckMacroName(id, name); err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	if _, err := dice.Parse(name); err == nil {
+		return fmt.Errorf("%s is a dice expression", name)
+	}
+	return nil
+}
+
+// completeMacros suggests the macros starting with the prefix.
+func completeMacros(agg *repository.CharacterAggregate, prefix string) []Completion {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	var out []Completion
+	for _, m := range agg.Macros {
+		if m.Name != "" && strings.HasPrefix(strings.ToLower(m.Name), prefix) {
+			out = append(out, Completion{Value: m.Name, Hint: m.Expression})
+		}
+	}
+	return out
+}
+
+type RollsAction struct{}
+
+func (a RollsAction) Name() string    { return "rolls" }
+func (a RollsAction) ArgHint() string { return "" }
+func (a RollsAction) Access() Access  { return ReadsOnly }
+
+func (a RollsAction) Execute(_ *repository.CharacterAggregate, _ string) ActionResult {
+	return ActionResult{Cmd: command.SwitchScreenCmd(command.RollLogScreenIndex)}
+}
+
+type MacrosAction struct{}
+
+func (a MacrosAction) Name() string    { return "macros" }
+func (a MacrosAction) ArgHint() string { return "" }
+func (a MacrosAction) Access() Access  { return ReadsOnly }
+
+func (a MacrosAction) Execute(_ *repository.CharacterAggregate, _ string) ActionResult {
+	return ActionResult{Cmd: command.SwitchScreenCmd(command.MacroScreenIndex)}
+}
+
 type ProbAction struct{}
 
 func (a ProbAction) Name() string    { return "prob" }
 func (a ProbAction) ArgHint() string { return "<expr cmp value>" }
+func (a ProbAction) Access() Access  { return ReadsOnly }
 
-func (a ProbAction) Execute(_ *repository.CharacterAggregate, args string) ActionResult {
+func (a ProbAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
 	args = strings.TrimSpace(args)
 	if args == "" {
 		return ActionResult{ErrMsg: "usage: prob <expr cmp value>"}
 	}
-	qr, err := dicestats.Query("P[" + args + "]")
+	expr, err := agg.ExpandVariables(args)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	qr, err := dicestats.Query("P[" + expr + "]")
 	if err != nil {
 		return ActionResult{ErrMsg: err.Error()}
 	}
@@ -118,20 +724,29 @@ func (a ProbAction) Execute(_ *repository.CharacterAggregate, args string) Actio
 	if qr.Approximate {
 		prefix = "~"
 	}
-	return ActionResult{Result: fmt.Sprintf("P = %s%.4f", prefix, qr.Value)}
+	return ActionResult{Result: withRollNote(agg, args, fmt.Sprintf("P = %s%.4f", prefix, qr.Value))}
 }
 
 type EvAction struct{}
 
 func (a EvAction) Name() string    { return "ev" }
-func (a EvAction) ArgHint() string { return "<expression>" }
+func (a EvAction) ArgHint() string { return "<expression|macro>" }
+func (a EvAction) Access() Access  { return ReadsOnly }
+
+func (a EvAction) Complete(agg *repository.CharacterAggregate, prefix string) []Completion {
+	return completeMacros(agg, prefix)
+}
 
-func (a EvAction) Execute(_ *repository.CharacterAggregate, args string) ActionResult {
+func (a EvAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
 	args = strings.TrimSpace(args)
 	if args == "" {
-		return ActionResult{ErrMsg: "usage: ev <expression>"}
+		return ActionResult{ErrMsg: "usage: ev <expression|macro>"}
+	}
+	expr, _, err := resolveExpression(agg, args)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
 	}
-	qr, err := dicestats.Query("E[" + args + "]")
+	qr, err := dicestats.Query("E[" + expr + "]")
 	if err != nil {
 		return ActionResult{ErrMsg: err.Error()}
 	}
@@ -139,34 +754,156 @@ func (a EvAction) Execute(_ *repository.CharacterAggregate, args string) ActionR
 	if qr.Approximate {
 		prefix = "~"
 	}
-	return ActionResult{Result: fmt.Sprintf("E = %s%.4f", prefix, qr.Value)}
+	return ActionResult{Result: withRollNote(agg, expr, fmt.Sprintf("E = %s%.4f", prefix, qr.Value))}
 }
 
-type DistAction struct{}
+// d20Rolls are the rolls besides saving throws noted by withRollNote.
+var d20Rolls = []struct {
+	label string
+	kind  models.RollKind
+}{
+	{"attack rolls", models.AttackRoll},
+	{"ability checks", models.AbilityCheck},
+}
 
-func (a DistAction) Name() string    { return "dist" }
-func (a DistAction) ArgHint() string { return "<expression>" }
+// withRollNote appends the rollNotes of the expression to the result.
+func withRollNote(agg *repository.CharacterAggregate, expr, result string) string {
+	return strings.Join(append([]string{result}, rollNotes(agg, expr)...), "\n")
+}
 
-func (a DistAction) Execute(_ *repository.CharacterAggregate, args string) ActionResult {
-	args = strings.TrimSpace(args)
-	if args == "" {
-		return ActionResult{ErrMsg: "usage: dist <expression>"}
+// rollNotes lists the conditions and exhaustion of the character affecting
+// d20 rolls if the expression rolls a d20. Saving throws are grouped if all
+// six are affected alike.
+func rollNotes(agg *repository.CharacterAggregate, expr string) []string {
+	if agg == nil || !strings.Contains(strings.ToLower(expr), "d20") {
+		return nil
 	}
-	qr, err := dicestats.Query("D[" + args + "]")
-	if err != nil {
-		return ActionResult{ErrMsg: err.Error()}
+	var sources []string
+	effects := map[string][]string{}
+	add := func(m repository.RollModifier, label string) {
+		if _, ok := effects[m.Source]; !ok {
+			sources = append(sources, m.Source)
+		}
+		effects[m.Source] = append(effects[m.Source], fmt.Sprintf("%s on %s", rollEffect(m), label))
 	}
-	d := qr.Distribution
-	prefix := ""
-	if qr.Approximate {
-		prefix = "~"
+	for _, roll := range d20Rolls {
+		for _, m := range agg.RollModifiers(roll.kind, models.NoAbility) {
+			add(m, roll.label)
+		}
+	}
+	var saves []repository.RollModifier
+	saveAbilities := map[repository.RollModifier][]models.Ability{}
+	for ability := models.Strength; ability <= models.Charisma; ability++ {
+		for _, m := range agg.RollModifiers(models.SavingThrow, ability) {
+			if _, ok := saveAbilities[m]; !ok {
+				saves = append(saves, m)
+			}
+			saveAbilities[m] = append(saveAbilities[m], ability)
+		}
+	}
+	for _, m := range saves {
+		if len(saveAbilities[m]) == models.AbilityCount {
+			add(m, "saving throws")
+			continue
+		}
+		for _, ability := range saveAbilities[m] {
+			add(m, ability.Short()+" saves")
+		}
+	}
+	notes := make([]string, 0, len(sources))
+	for _, source := range sources {
+		notes = append(notes, source+": "+strings.Join(effects[source], ", "))
+	}
+	return notes
+}
+
+func rollEffect(m repository.RollModifier) string {
+	if m.Effect != models.NoEffect {
+		return m.Effect.String()
+	}
+	return fmt.Sprintf("%+d", m.Bonus)
+}
+
+// State snapshots recorded in the session log around gameplay actions.
+
+func concentrationState(agg *repository.CharacterAggregate) string {
+	if !agg.Concentrating() {
+		return "not concentrating"
+	}
+	return "concentrating on " + agg.ConcentrationLabel()
+}
+
+func castState(agg *repository.CharacterAggregate, level int) string {
+	state := concentrationState(agg)
+	if level > 0 {
+		state = slotState(agg, level) + ", " + state
+	}
+	return state
+}
+
+func hpState(agg *repository.CharacterAggregate) string {
+	c := agg.Character
+	s := fmt.Sprintf("HP %d/%d", c.CurrHitPoints, agg.HitPointMaximum())
+	if c.TempHitPoints > 0 {
+		s += fmt.Sprintf(" (+%d)", c.TempHitPoints)
+	}
+	return s
+}
+
+func slotState(agg *repository.CharacterAggregate, level int) string {
+	c := agg.Character
+	slots := agg.SpellSlots()
+	if level >= len(c.SpellSlotsUsed) {
+		return ""
+	}
+	state := fmt.Sprintf("L%d slots %d/%d used", level, c.SpellSlotsUsed[level], slots[level])
+	if pactSlots, pactLevel := agg.PactSlots(); pactSlots > 0 && pactLevel >= level {
+		state += fmt.Sprintf(", pact slots %d/%d used", c.PactSlotsUsed, pactSlots)
+	}
+	return state
+}
+
+func restState(agg *repository.CharacterAggregate) string {
+	used := agg.Character.PactSlotsUsed
+	for _, u := range agg.Character.SpellSlotsUsed {
+		used += u
+	}
+	state := fmt.Sprintf("%s, %d slots used", hpState(agg), used)
+	if agg.Character.Exhaustion > 0 {
+		state += fmt.Sprintf(", exhaustion %d", agg.Character.Exhaustion)
+	}
+	return withRestored(agg, state)
+}
+
+func shortRestState(agg *repository.CharacterAggregate) string {
+	state := hpState(agg)
+	if pactSlots, _ := agg.PactSlots(); pactSlots > 0 {
+		state += fmt.Sprintf(", pact slots %d/%d used", agg.Character.PactSlotsUsed, pactSlots)
+	}
+	return withRestored(agg, state)
+}
+
+// withRestored appends the remaining hit dice and resources, if any.
+func withRestored(agg *repository.CharacterAggregate, state string) string {
+	if len(agg.Classes) > 0 {
+		state += ", hit dice " + hitDice(agg)
+	}
+	for i := range agg.Resources {
+		r := &agg.Resources[i]
+		state += fmt.Sprintf(", %s %d/%d", r.Name, agg.ResourceRemaining(r), agg.ResourceMax(r))
 	}
-	result := fmt.Sprintf(
-		"%smean: %.2f  std: %.2f\nmin:  %d      max: %d\nmode: %d      med: %d",
-		prefix,
-		d.Expected(), d.StdDev(),
-		d.Min(), d.Max(),
-		d.Mode(), d.Median(),
-	)
-	return ActionResult{Result: result}
+	return state
+}
+
+func hitDice(agg *repository.CharacterAggregate) string {
+	pools := agg.HitDice()
+	parts := make([]string, len(pools))
+	for i, p := range pools {
+		parts[i] = fmt.Sprintf("%d/%dd%d", p.Remaining(), p.Total, p.Die)
+	}
+	return strings.Join(parts, " ")
+}
+
+func attunementState(agg *repository.CharacterAggregate) string {
+	return fmt.Sprintf("%d/%d attuned", agg.AttunedSlots(), agg.Character.AttunementLimit)
 }
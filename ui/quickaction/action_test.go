@@ -15,10 +15,10 @@ import (
 func charAgg(curr, max int, slots, used []int) *repository.CharacterAggregate {
 	return &repository.CharacterAggregate{
 		Character: &models.CharacterTO{
-			CurrHitPoints:  curr,
-			MaxHitPoints:   max,
-			SpellSlots:     slots,
-			SpellSlotsUsed: used,
+			CurrHitPoints:      curr,
+			MaxHitPoints:       max,
+			SpellSlotOverrides: models.Overrides(slots),
+			SpellSlotsUsed:     used,
 		},
 	}
 }
@@ -79,7 +79,7 @@ func TestCastAction(t *testing.T) {
 		wantUsed int    // expected SpellSlotsUsed[checkIdx]
 	}{
 		{"valid cast increments slot", []int{0, 0, 0, 2}, []int{0, 0, 0, 0}, "3", "", 3, 1},
-		{"non-numeric arg", []int{0, 2}, []int{0, 0}, "abc", "usage", 1, 0},
+		{"unknown spell", []int{0, 2}, []int{0, 0}, "abc", "no spell named", 1, 0},
 		{"empty arg", []int{0, 2}, []int{0, 0}, "", "usage", 1, 0},
 		{"level below range", []int{0, 2}, []int{0, 0}, "0", "usage", 1, 0},
 		{"level above range", make([]int, 10), make([]int, 10), "10", "usage", 1, 0},
@@ -104,6 +104,39 @@ func TestCastAction(t *testing.T) {
 	}
 }
 
+func TestCastConcentrationSpell(t *testing.T) {
+	agg := charAgg(10, 10, []int{0, 2, 1}, []int{0, 0, 0})
+	agg.Spells = []models.SpellTO{
+		{Name: "Bless", Level: 1, Concentration: 1},
+		{Name: "Hold Person", Level: 2, Concentration: 1},
+		{Name: "Guidance", Level: 0, Concentration: 1},
+	}
+
+	res := CastAction{}.Execute(agg, "bless")
+	assertWriteBack(t, res)
+	if res.Result != "" || agg.Character.ConcentrationSpell != "Bless" {
+		t.Errorf("expected to concentrate on Bless silently, got %q on %q", res.Result, agg.Character.ConcentrationSpell)
+	}
+
+	res = CastAction{}.Execute(agg, "Hold Person 2")
+	assertWriteBack(t, res)
+	if res.Result != "concentrating on Hold Person, Bless ended" {
+		t.Errorf("Result = %q", res.Result)
+	}
+	if got := agg.Character.SpellSlotsUsed; got[1] != 1 || got[2] != 1 {
+		t.Errorf("SpellSlotsUsed = %v, want one slot each of level 1 and 2", got)
+	}
+
+	assertErr(t, CastAction{}.Execute(agg, "bless 3"), "no spell slots at level 3")
+	assertErr(t, CastAction{}.Execute(agg, "hold person 1"), "level 2 spell")
+
+	res = CastAction{}.Execute(agg, "guidance")
+	assertWriteBack(t, res)
+	if res.Result != "concentrating on Guidance, Hold Person ended" {
+		t.Errorf("Result = %q", res.Result)
+	}
+}
+
 func TestHealAction(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -164,6 +197,287 @@ func TestDmgAction(t *testing.T) {
 	}
 }
 
+func TestDmgActionWhileConcentrating(t *testing.T) {
+	agg := charAgg(30, 30, nil, nil)
+	agg.Character.Concentration = 1
+	agg.Character.ConcentrationSpell = "Bless"
+
+	res := DmgAction{}.Execute(agg, "24")
+	assertWriteBack(t, res)
+	if res.Result != "concentration on Bless: DC 12 Con save, enter to roll" || res.Suggest != "consave 12" {
+		t.Errorf("Result = %q, Suggest = %q", res.Result, res.Suggest)
+	}
+
+	res = DmgAction{}.Execute(agg, "10")
+	if res.Result != "concentration on Bless ended" || agg.Concentrating() {
+		t.Errorf("expected concentration to end at 0 HP, got %q", res.Result)
+	}
+}
+
+func TestConSaveAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		rolls      []int
+		exhaustion int
+		rules      models.ExhaustionRules
+		wantResult string
+		wantHeld   bool
+	}{
+		{"holds", []int{12}, 0, models.TieredExhaustion, "Con save 12 (d20: 12) vs DC 12, concentration on Bless holds", true},
+		{"ends", []int{11}, 0, models.TieredExhaustion, "Con save 11 (d20: 11) vs DC 12, concentration on Bless ends", false},
+		{"disadvantage keeps the lower roll", []int{15, 8}, 3, models.TieredExhaustion,
+			"Con save 8 (d20: 8) vs DC 12, concentration on Bless ends", false},
+		{"exhaustion penalty", []int{13}, 1, models.PenaltyExhaustion,
+			"Con save 11 (d20: 13) vs DC 12, concentration on Bless ends", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := charAgg(30, 30, nil, nil)
+			agg.Character.Concentration = 1
+			agg.Character.ConcentrationSpell = "Bless"
+			agg.Character.Exhaustion = tt.exhaustion
+			agg.Character.ExhaustionRules = int(tt.rules)
+
+			rolls := tt.rolls
+			roll := func(int) int {
+				r := rolls[0]
+				rolls = rolls[1:]
+				return r
+			}
+			res := ConSaveAction{Roll: roll}.Execute(agg, "12")
+			assertWriteBack(t, res)
+			if res.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", res.Result, tt.wantResult)
+			}
+			if agg.Concentrating() != tt.wantHeld {
+				t.Errorf("Concentrating() = %t, want %t", agg.Concentrating(), tt.wantHeld)
+			}
+		})
+	}
+	assertErr(t, ConSaveAction{}.Execute(charAgg(30, 30, nil, nil), "12"), "not concentrating")
+}
+
+// sequence returns the given rolls in order.
+func sequence(rolls ...int) func(int) int {
+	return func(int) int {
+		r := rolls[0]
+		rolls = rolls[1:]
+		return r
+	}
+}
+
+// d20Agg has Dexterity 16 and proficiency in Stealth, i.e. Stealth +5.
+func d20Agg(conditions ...models.Condition) *repository.CharacterAggregate {
+	agg := charAgg(10, 10, nil, nil)
+	agg.Abilities = &models.AbilitiesTO{Strength: 10, Dexterity: 16, Constitution: 10, Intelligence: 10, Wisdom: 10, Charisma: 10}
+	agg.SavingThrows = &models.SavingThrowsTO{}
+	agg.Skills = []models.CharacterSkillDetailTO{{SkillName: "Stealth", SkillAbility: "Dexterity", Proficiency: int(models.Proficient)}}
+	for _, c := range conditions {
+		agg.Conditions = append(agg.Conditions, models.ConditionTO{Condition: int(c)})
+	}
+	return agg
+}
+
+func TestCheckAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []models.Condition
+		args       string
+		rolls      []int
+		wantResult string
+	}{
+		{"plain", nil, "stealth", []int{12}, "Stealth check 17 (1d20: 12, +5)"},
+		{"advantage", nil, "Stealth adv", []int{7, 12}, "Stealth check 17 (2d20kh1: (7) 12, +5)"},
+		{"poisoned", []models.Condition{models.Poisoned}, "stea", []int{12, 7},
+			"Stealth check 12 (2d20kl1: (12) 7, +5)\nPoisoned: disadvantage"},
+		{"advantage cancels disadvantage", []models.Condition{models.Poisoned}, "stealth adv", []int{12},
+			"Stealth check 17 (1d20: 12, +5)\nPoisoned: disadvantage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := d20Agg(tt.conditions...)
+			res := CheckAction{Roll: sequence(tt.rolls...)}.Execute(agg, tt.args)
+			assertWriteBack(t, res)
+			if res.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", res.Result, tt.wantResult)
+			}
+			if len(agg.Rolls) != 1 || agg.Rolls[0].Expression != "Stealth check" {
+				t.Errorf("expected the check in the roll log, got %+v", agg.Rolls)
+			}
+		})
+	}
+	assertErr(t, CheckAction{}.Execute(d20Agg(), " adv"), "usage")
+	assertErr(t, CheckAction{}.Execute(d20Agg(), "arcana"), "no skill named")
+}
+
+func TestSaveAction(t *testing.T) {
+	agg := d20Agg(models.Paralyzed)
+	res := SaveAction{Roll: sequence(20)}.Execute(agg, "dex")
+	assertWriteBack(t, res)
+	if want := "Dex save 23 (1d20: 20, +3), automatic failure\nParalyzed: automatic failure"; res.Result != want {
+		t.Errorf("Result = %q, want %q", res.Result, want)
+	}
+
+	agg = d20Agg()
+	agg.Character.Exhaustion = 2
+	agg.Character.ExhaustionRules = int(models.PenaltyExhaustion)
+	res = SaveAction{Roll: sequence(10)}.Execute(agg, "Constitution")
+	if want := "Con save 6 (1d20: 10, -4)\nExhaustion 2: -4"; res.Result != want {
+		t.Errorf("Result = %q, want %q", res.Result, want)
+	}
+
+	assertErr(t, SaveAction{}.Execute(agg, ""), "usage")
+	assertErr(t, SaveAction{}.Execute(agg, "luck"), "usage")
+}
+
+func TestAttackAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []models.Condition
+		args       string
+		rolls      []int
+		wantResult string
+		wantLogged int
+	}{
+		{"without target", nil, "long", []int{14, 6},
+			"Longsword 19 to hit (1d20: 14, +5)\n9 slashing damage (1d8: 6)", 2},
+		{"miss", nil, "longsword vs 20", []int{14},
+			"Longsword 19 to hit (1d20: 14, +5) vs AC 20, miss", 1},
+		{"critical hit doubles the dice", nil, "Longsword vs 15 adv", []int{3, 20, 6, 2},
+			"Longsword 25 to hit (2d20kh1: (3) 20, +5) vs AC 15, critical hit\n11 slashing damage (1d8: 6, 1d8: 2)", 2},
+		{"natural 1", nil, "longsword vs 5", []int{1},
+			"Longsword 6 to hit (1d20: 1, +5) vs AC 5, natural 1 misses", 1},
+		{"poisoned", []models.Condition{models.Poisoned}, "longsword vs 14", []int{14, 9, 6},
+			"Longsword 14 to hit (2d20kl1: (14) 9, +5) vs AC 14, hit\n9 slashing damage (1d8: 6)\nPoisoned: disadvantage", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := d20Agg(tt.conditions...)
+			agg.Attacks = []models.AttackTO{{Name: "Longsword", Bonus: 5, Damage: "1d8 + @dex", DamageType: "slashing"}}
+			res := AttackAction{Roll: sequence(tt.rolls...)}.Execute(agg, tt.args)
+			assertWriteBack(t, res)
+			if res.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", res.Result, tt.wantResult)
+			}
+			if len(agg.Rolls) != tt.wantLogged {
+				t.Errorf("expected %d logged rolls, got %d", tt.wantLogged, len(agg.Rolls))
+			}
+		})
+	}
+
+	agg := d20Agg()
+	agg.Attacks = []models.AttackTO{{Name: "Longsword", Bonus: 5, Damage: "1d8 +"}}
+	assertErr(t, AttackAction{}.Execute(agg, "vs 15"), "usage")
+	assertErr(t, AttackAction{}.Execute(agg, "axe"), "no attack named")
+	assertErr(t, AttackAction{}.Execute(agg, "longsword"), "damage of Longsword")
+	agg.Attacks[0].Damage = "1d8 + @foo"
+	assertErr(t, AttackAction{}.Execute(agg, "longsword"), "unknown variable @foo")
+}
+
+func TestRestsRechargeResources(t *testing.T) {
+	newAgg := func() *repository.CharacterAggregate {
+		agg := charAgg(10, 20, nil, nil)
+		agg.Resources = []models.ResourceTO{
+			{Name: "Ki", Maximum: 4, Used: 3, Recharge: int(models.ShortRestRecharge)},
+			{Name: "Rage", Maximum: 3, Used: 2, Recharge: int(models.LongRestRecharge)},
+		}
+		return agg
+	}
+
+	agg := newAgg()
+	assertWriteBack(t, ShortRestAction{}.Execute(agg, ""))
+	if e := agg.Events[0]; e.Before != "HP 10/20, Ki 1/4, Rage 1/3" || e.After != "HP 10/20, Ki 4/4, Rage 1/3" {
+		t.Errorf("logged %+v", e)
+	}
+
+	agg = newAgg()
+	assertWriteBack(t, LongRestAction{}.Execute(agg, ""))
+	if e := agg.Events[0]; e.After != "HP 20/20, 0 slots used, Ki 4/4, Rage 3/3" {
+		t.Errorf("logged after = %q", e.After)
+	}
+}
+
+func TestShortRestAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       string
+		wantErr    string
+		wantResult string
+		wantCurr   int
+		wantState  string
+	}{
+		{"count takes largest dice first", "3", "", "d10: 4, d10: 4, d6: 4, healed 12", 22, "0/2d10 1/3d6"},
+		{"dice per size", "1d6 d10", "", "d6: 4, d10: 4, healed 8", 18, "1/2d10 1/3d6"},
+		{"count above remaining", "5", "not enough hit dice (4 left)", "", 10, "2/2d10 2/3d6"},
+		{"size above remaining", "3d6", "not enough d6 hit dice (2 left)", "", 10, "2/2d10 2/3d6"},
+		{"unknown size", "1d8", "no d8 hit dice", "", 10, "2/2d10 2/3d6"},
+		{"malformed dice", "2x6", "usage", "", 10, "2/2d10 2/3d6"},
+		{"no dice", "", "", "no hit dice spent", 10, "2/2d10 2/3d6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := charAgg(10, 30, nil, nil)
+			agg.Classes = []models.CharacterClassTO{
+				{ClassName: "Wizard", Level: 3, HitDie: 6, HitDiceUsed: 1},
+				{ClassName: "Fighter", Level: 2, HitDie: 10},
+			}
+			res := ShortRestAction{Roll: func(int) int { return 4 }}.Execute(agg, tt.args)
+			if tt.wantErr == "" {
+				assertWriteBack(t, res)
+			} else {
+				assertErr(t, res, tt.wantErr)
+			}
+			if res.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", res.Result, tt.wantResult)
+			}
+			if agg.Character.CurrHitPoints != tt.wantCurr {
+				t.Errorf("CurrHitPoints = %d, want %d", agg.Character.CurrHitPoints, tt.wantCurr)
+			}
+			if got := hitDice(agg); got != tt.wantState {
+				t.Errorf("hit dice = %q, want %q", got, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestAttuneAction(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        string
+		wantErr     string
+		wantAttuned int
+	}{
+		{"attunes by prefix", "cloak", "", 2},
+		{"item without attunement", "rope", "does not require attunement", 1},
+		{"already attuned", "ring of protection", "already attuned", 1},
+		{"exceeds the limit", "staff", "exceeds the limit of 2", 1},
+		{"unknown item", "sword", "no item named", 1},
+		{"ambiguous prefix", "r", "matches 2 items", 1},
+		{"missing item", " ", "usage", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := charAgg(10, 20, nil, nil)
+			agg.Character.AttunementLimit = 2
+			agg.Items = []models.ItemTO{
+				{Name: "Ring of Protection", AttunementSlots: 1, Attuned: 1},
+				{Name: "Cloak of Elvenkind", AttunementSlots: 1},
+				{Name: "Staff of Power", AttunementSlots: 2},
+				{Name: "Rope"},
+			}
+			res := AttuneAction{}.Execute(agg, tt.args)
+			if tt.wantErr == "" {
+				assertWriteBack(t, res)
+			} else {
+				assertErr(t, res, tt.wantErr)
+			}
+			if got := agg.AttunedSlots(); got != tt.wantAttuned {
+				t.Errorf("AttunedSlots() = %d, want %d", got, tt.wantAttuned)
+			}
+		})
+	}
+}
+
 // The calculator actions delegate their math to the dicestats package; only
 // the DnC-owned arg validation is exercised here.
 func TestCalculatorActionsRejectEmptyArgs(t *testing.T) {
@@ -191,3 +505,170 @@ func TestCalculatorActionsRejectEmptyArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestRollAction(t *testing.T) {
+	agg := charAgg(10, 10, nil, nil)
+	rolls := []int{6, 1, 5, 4}
+	roll := func(int) int {
+		r := rolls[0]
+		rolls = rolls[1:]
+		return r
+	}
+	res := RollAction{Roll: roll}.Execute(agg, " 4d6kh3 + 2 ")
+	assertWriteBack(t, res)
+	if want := "17 (4d6kh3: 6 (1) 5 4)"; res.Result != want {
+		t.Errorf("Result = %q, want %q", res.Result, want)
+	}
+	if len(agg.Rolls) != 1 {
+		t.Fatalf("expected 1 logged roll, got %d", len(agg.Rolls))
+	}
+	if r := agg.Rolls[0]; r.Expression != "4d6kh3 + 2" || r.Total != 17 || r.Detail != "4d6kh3: 6 (1) 5 4" {
+		t.Errorf("logged %+v", r)
+	}
+
+	assertErr(t, RollAction{}.Execute(agg, ""), "usage")
+	assertErr(t, RollAction{}.Execute(agg, "2d"), "number of sides")
+	if len(agg.Rolls) != 1 {
+		t.Errorf("failed rolls must not be logged, got %d rolls", len(agg.Rolls))
+	}
+}
+
+func TestRollActionExpandsVariables(t *testing.T) {
+	agg := d20Agg()
+	res := RollAction{Roll: sequence(12)}.Execute(agg, "1d20 + @stealth")
+	assertWriteBack(t, res)
+	if want := "17 (1d20: 12)"; res.Result != want {
+		t.Errorf("Result = %q, want %q", res.Result, want)
+	}
+	if r := agg.Rolls[0]; r.Expression != "1d20 + 5" {
+		t.Errorf("logged expression %q, want the expanded one", r.Expression)
+	}
+	assertErr(t, RollAction{}.Execute(agg, "1d20 + @luck"), "unknown variable @luck")
+	for _, a := range []Action{ProbAction{}, EvAction{}, DistAction{}} {
+		assertErr(t, a.Execute(agg, "1d20 + @luck"), "unknown variable @luck")
+	}
+}
+
+func TestRollMacro(t *testing.T) {
+	agg := d20Agg()
+	agg.Macros = []models.MacroTO{{Name: "Sneak", Expression: "3d6 + @dex"}}
+	res := RollAction{Roll: sequence(2, 4, 6)}.Execute(agg, "sneak")
+	assertWriteBack(t, res)
+	if want := "15 (3d6: 2 4 6)"; res.Result != want {
+		t.Errorf("Result = %q, want %q", res.Result, want)
+	}
+	if r := agg.Rolls[0]; r.Expression != "Sneak: 3d6 + 3" {
+		t.Errorf("logged expression %q", r.Expression)
+	}
+
+	got := RollAction{}.Complete(agg, "SN")
+	if len(got) != 1 || got[0] != (Completion{Value: "Sneak", Hint: "3d6 + @dex"}) {
+		t.Errorf("Complete(SN) = %v", got)
+	}
+	if got := (EvAction{}).Complete(agg, "smite"); len(got) != 0 {
+		t.Errorf("Complete(smite) = %v, want none", got)
+	}
+}
+
+func TestCheckMacroName(t *testing.T) {
+	agg := d20Agg()
+	smite := agg.AddEmptyMacro()
+	agg.Macros[0].Name = "Smite"
+	other := agg.AddEmptyMacro()
+
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{"Hex", ""},
+		{"smite", "named Smite exists"},
+		{"d20", "dice expression"},
+		{" 2d6 ", "dice expression"},
+		{"4d6kh3", "dice expression"},
+	}
+	for _, tt := range tests {
+		err := CheckMacroName(agg, other, tt.name)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("CheckMacroName(%q) = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+	if err := CheckMacroName(agg, smite, "Smite"); err != nil {
+		t.Errorf("CheckMacroName(Smite) = %v for its own macro", err)
+	}
+}
+
+func TestRollNote(t *testing.T) {
+	agg := charAgg(10, 10, nil, nil)
+	agg.Conditions = []models.ConditionTO{{Condition: int(models.Poisoned)}}
+
+	got := withRollNote(agg, "d20 >= 11", "P = 0.5000")
+	want := "P = 0.5000\nPoisoned: disadvantage on attack rolls, disadvantage on ability checks"
+	if got != want {
+		t.Errorf("withRollNote() = %q, want %q", got, want)
+	}
+	if got := withRollNote(agg, "2d6", "E = 7.0000"); got != "E = 7.0000" {
+		t.Errorf("expected no note without a d20, got %q", got)
+	}
+
+	agg.Conditions = []models.ConditionTO{{Condition: int(models.Restrained)}}
+	agg.Character.Exhaustion = 2
+	agg.Character.ExhaustionRules = int(models.PenaltyExhaustion)
+	got = withRollNote(agg, "1d20+5", "E = 15.5000")
+	want = "E = 15.5000\nRestrained: disadvantage on attack rolls, disadvantage on Dex saves" +
+		"\nExhaustion 2: -4 on attack rolls, -4 on ability checks, -4 on saving throws"
+	if got != want {
+		t.Errorf("withRollNote() = %q, want %q", got, want)
+	}
+}
+
+func TestGameplayActionsLogEvents(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     Action
+		args       string
+		wantBefore string
+		wantAfter  string
+	}{
+		{"dmg", DmgAction{}, "4", "HP 10/20", "HP 6/20"},
+		{"heal", HealAction{}, "15", "HP 10/20", "HP 20/20"},
+		{"thp", TempHPAction{}, "5", "HP 10/20", "HP 10/20 (+5)"},
+		{"cast", CastAction{}, "1", "L1 slots 0/2 used", "L1 slots 1/2 used"},
+		{"longrest", LongRestAction{}, "", "HP 10/20, 0 slots used", "HP 20/20, 0 slots used"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := charAgg(10, 20, []int{0, 2}, []int{0, 0})
+			assertWriteBack(t, tt.action.Execute(agg, tt.args))
+			if len(agg.Events) != 1 {
+				t.Fatalf("expected 1 logged event, got %d", len(agg.Events))
+			}
+			e := agg.Events[0]
+			if e.Action != tt.name || e.Args != tt.args || e.Before != tt.wantBefore || e.After != tt.wantAfter {
+				t.Errorf("logged %+v, want action=%q args=%q before=%q after=%q", e, tt.name, tt.args, tt.wantBefore, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestFailedActionIsNotLogged(t *testing.T) {
+	agg := charAgg(10, 20, []int{0, 0}, []int{0, 0})
+	assertErr(t, CastAction{}.Execute(agg, "1"), "no spell slots")
+	if len(agg.Events) != 0 {
+		t.Errorf("expected no logged events, got %d", len(agg.Events))
+	}
+}
+
+// Actions that write back must be marked so read-only mode can block them.
+func TestWriteBackActionsAreNotReadsOnly(t *testing.T) {
+	args := map[string]string{"cast": "1", "heal": "1", "dmg": "1", "thp": "1", "roll": "1d20"}
+	for _, a := range NewRegistry().All() {
+		agg := charAgg(10, 20, []int{0, 2}, []int{0, 0})
+		r := a.Execute(agg, args[a.Name()])
+		if r.Cmd == nil {
+			continue
+		}
+		if _, ok := r.Cmd().(command.WriteBackRequestMsg); ok && accessOf(a) == ReadsOnly {
+			t.Errorf("%s writes back but declares ReadsOnly", a.Name())
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package quickaction
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/dice"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/styles"
+)
+
+// Target ACs analyzed unless given, and the most shown at once.
+const (
+	DefaultMinAC = 10
+	DefaultMaxAC = 20
+	maxACRows    = 30
+)
+
+var analysisWidth = styles.SmallScreenWidth - 4
+
+// AnalyzeAction shows the hit chance and expected damage of an attack
+// against a range of ACs in the reader.
+type AnalyzeAction struct{}
+
+func (a AnalyzeAction) Name() string    { return "analyze" }
+func (a AnalyzeAction) ArgHint() string { return "<attack> [AC-AC] [adv|dis]" }
+func (a AnalyzeAction) Access() Access  { return ReadsOnly }
+
+func (a AnalyzeAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	name, asked := parseAdvantage(args)
+	name, lo, hi, err := parseACRange(name)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	if name == "" {
+		return ActionResult{ErrMsg: "usage: analyze <attack> [AC-AC] [adv|dis]"}
+	}
+	attack, err := agg.FindAttack(name)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	return ActionResult{Cmd: AttackAnalysisCmd(agg, attack, lo, hi, asked)}
+}
+
+var acRange = regexp.MustCompile(`^(\d+)(?:-(\d+))?$`)
+
+// parseACRange splits a trailing AC or range of ACs, e.g. "12-18", off the
+// arguments.
+func parseACRange(args string) (string, int, int, error) {
+	fields := strings.Fields(args)
+	n := len(fields)
+	if n < 2 {
+		return args, DefaultMinAC, DefaultMaxAC, nil
+	}
+	m := acRange.FindStringSubmatch(fields[n-1])
+	if m == nil {
+		return strings.Join(fields, " "), DefaultMinAC, DefaultMaxAC, nil
+	}
+	lo, _ := strconv.Atoi(m[1])
+	hi := lo
+	if m[2] != "" {
+		hi, _ = strconv.Atoi(m[2])
+	}
+	if lo > hi || hi-lo >= maxACRows {
+		return "", 0, 0, fmt.Errorf("AC range %s is not within %d ACs", fields[n-1], maxACRows)
+	}
+	return strings.Join(fields[:n-1], " "), lo, hi, nil
+}
+
+// attackAnalysis is what the analysis of an attack takes from the
+// character, so the statistics can be queried outside of Update.
+type attackAnalysis struct {
+	lines   []string
+	bonus   int
+	mode    dice.D20Mode
+	damage  string
+	formula string
+	err     error
+	lo, hi  int
+}
+
+// AttackAnalysisCmd opens the chance to hit each AC from lo to hi, the
+// chance of a critical hit and the expected damage per attack in the reader,
+// followed by the damage distributions. Advantage or disadvantage asked for
+// combines with the conditions of the character.
+func AttackAnalysisCmd(agg *repository.CharacterAggregate, attack *models.AttackTO, lo, hi int, asked models.RollEffect) tea.Cmd {
+	a := newAttackAnalysis(agg, attack, lo, hi, asked)
+	return func() tea.Msg {
+		return command.LaunchReaderScreenMsg{Content: a.render()}
+	}
+}
+
+func newAttackAnalysis(agg *repository.CharacterAggregate, attack *models.AttackTO, lo, hi int, asked models.RollEffect) attackAnalysis {
+	a := attackAnalysis{bonus: agg.AttackBonus(attack), damage: attack.Damage, lo: lo, hi: hi}
+	title := fmt.Sprintf("%s: %+d to hit", attack.Name, a.bonus)
+	a.formula, a.err = agg.ExpandVariables(attack.Damage)
+	if attack.Damage != "" && a.err == nil {
+		title += ", " + strings.TrimSpace(a.formula+" "+attack.DamageType)
+	}
+	a.lines = []string{title, styles.MakeHorizontalSeparator(analysisWidth, 1)}
+
+	sources := agg.RollModifiers(models.AttackRoll, models.NoAbility)
+	a.mode = d20Mode(sources, asked)
+	switch a.mode {
+	case dice.Advantage:
+		a.lines = append(a.lines, "Rolled with advantage.")
+	case dice.Disadvantage:
+		a.lines = append(a.lines, "Rolled with disadvantage.")
+	}
+	for _, m := range sources {
+		a.lines = append(a.lines, m.Source+": "+rollEffect(m))
+	}
+	return a
+}
+
+// render queries the damage distribution, once on a hit and once on a
+// critical hit.
+func (a attackAnalysis) render() string {
+	lines := slices.Clone(a.lines)
+	var damage, critical dice.Stats
+	var criticalFormula string
+	hasDamage := false
+	if a.damage != "" {
+		err := a.err
+		var expr dice.Expr
+		if err == nil {
+			expr, err = dice.Parse(a.formula)
+		}
+		if err == nil {
+			damage, err = dice.Distribution(a.formula)
+		}
+		if err == nil {
+			criticalFormula = expr.CriticalString()
+			critical, err = dice.Distribution(criticalFormula)
+		}
+		if err != nil {
+			lines = append(lines, "Damage: "+err.Error())
+		} else {
+			hasDamage = true
+			lines = append(lines,
+				fmt.Sprintf("On a hit:      %s%.2f mean, %d-%d", approx(damage), damage.Mean, damage.Min, damage.Max),
+				fmt.Sprintf("Critical hit:  %s%.2f mean, %d-%d", approx(critical), critical.Mean, critical.Min, critical.Max))
+		}
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%-4s %8s %8s %15s", "AC", "Hit", "Crit", "Damage/attack"))
+	for ac := a.lo; ac <= a.hi; ac++ {
+		hit, crit := dice.AttackChances(a.bonus, ac, a.mode)
+		expected := "-"
+		if hasDamage {
+			expected = fmt.Sprintf("%.2f", (hit-crit)*damage.Mean+crit*critical.Mean)
+		}
+		lines = append(lines, fmt.Sprintf("%-4d %7.1f%% %7.1f%% %15s", ac, 100*hit, 100*crit, expected))
+	}
+
+	if hasDamage {
+		lines = append(lines, "", "Damage", styles.MakeHorizontalSeparator(analysisWidth, 1))
+		lo, hi := min(damage.Min, critical.Min), max(damage.Max, critical.Max)
+		hit, err := dice.NewHistogram(a.formula, damage, lo, hi)
+		var crit dice.Histogram
+		if err == nil {
+			crit, err = dice.NewHistogram(criticalFormula, critical, lo, hi)
+		}
+		if err != nil {
+			lines = append(lines, err.Error())
+		} else {
+			lines = append(lines, renderHistograms([]string{"On a hit", "Critical hit"}, []dice.Histogram{hit, crit}, analysisWidth, false)...)
+		}
+	}
+	return styles.DefaultTextStyle.
+		Width(analysisWidth).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
+
+// d20Mode combines the advantage or disadvantage asked for with the effects
+// of the sources, both cancel each other out.
+func d20Mode(sources []repository.RollModifier, asked models.RollEffect) dice.D20Mode {
+	adv, dis := asked == models.Advantage, asked == models.Disadvantage
+	for _, m := range sources {
+		adv = adv || m.Effect == models.Advantage
+		dis = dis || m.Effect == models.Disadvantage
+	}
+	switch {
+	case adv && !dis:
+		return dice.Advantage
+	case dis && !adv:
+		return dice.Disadvantage
+	}
+	return dice.Straight
+}
+
+func approx(s dice.Stats) string {
+	if s.Approximate {
+		return "~"
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package quickaction
+
+import (
+	"strings"
+	"testing"
+
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/dice"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+)
+
+func TestParseACRange(t *testing.T) {
+	tests := []struct {
+		args     string
+		wantName string
+		lo, hi   int
+	}{
+		{"longsword", "longsword", DefaultMinAC, DefaultMaxAC},
+		{"long sword 12-18", "long sword", 12, 18},
+		{"longsword 15", "longsword", 15, 15},
+		{"dagger +1", "dagger +1", DefaultMinAC, DefaultMaxAC},
+	}
+	for _, tt := range tests {
+		name, lo, hi, err := parseACRange(tt.args)
+		if err != nil || name != tt.wantName || lo != tt.lo || hi != tt.hi {
+			t.Errorf("parseACRange(%q) = %q, %d, %d, %v, want %q, %d, %d", tt.args, name, lo, hi, err, tt.wantName, tt.lo, tt.hi)
+		}
+	}
+	for _, args := range []string{"longsword 18-12", "longsword 1-40"} {
+		if _, _, _, err := parseACRange(args); err == nil {
+			t.Errorf("parseACRange(%q) should fail", args)
+		}
+	}
+}
+
+func TestD20Mode(t *testing.T) {
+	poisoned := []repository.RollModifier{{Source: "Poisoned", Effect: models.Disadvantage}}
+	tests := []struct {
+		name    string
+		sources []repository.RollModifier
+		asked   models.RollEffect
+		want    dice.D20Mode
+	}{
+		{"straight", nil, models.NoEffect, dice.Straight},
+		{"asked", nil, models.Advantage, dice.Advantage},
+		{"condition", poisoned, models.NoEffect, dice.Disadvantage},
+		{"both cancel", poisoned, models.Advantage, dice.Straight},
+	}
+	for _, tt := range tests {
+		if got := d20Mode(tt.sources, tt.asked); got != tt.want {
+			t.Errorf("%s: d20Mode() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAttackAnalysis(t *testing.T) {
+	agg := d20Agg(models.Poisoned)
+	attack := &models.AttackTO{Name: "Unarmed", Bonus: 5}
+	got := newAttackAnalysis(agg, attack, 15, 16, models.NoEffect).render()
+	for _, want := range []string{
+		"Unarmed: +5 to hit",
+		"Rolled with disadvantage.",
+		"Poisoned: disadvantage",
+		"15      30.3%     0.3%               -",
+		"16      25.0%     0.3%               -",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in the analysis:\n%s", want, got)
+		}
+	}
+}
+
+func TestAnalyzeAction(t *testing.T) {
+	agg := d20Agg()
+	agg.Attacks = []models.AttackTO{{Name: "Unarmed", Bonus: 5}}
+	res := AnalyzeAction{}.Execute(agg, "unarmed 12-14 adv")
+	if res.ErrMsg != "" || res.Cmd == nil {
+		t.Fatalf("expected the reader to open, got %+v", res)
+	}
+	msg, ok := res.Cmd().(command.LaunchReaderScreenMsg)
+	if !ok || !strings.Contains(msg.Content, "Rolled with advantage.") {
+		t.Errorf("unexpected Cmd result %+v", res.Cmd())
+	}
+	assertErr(t, AnalyzeAction{}.Execute(agg, ""), "usage")
+	assertErr(t, AnalyzeAction{}.Execute(agg, "axe"), "no attack named")
+	assertErr(t, AnalyzeAction{}.Execute(agg, "unarmed 20-10"), "AC range")
+}
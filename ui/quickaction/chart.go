@@ -0,0 +1,120 @@
+package quickaction
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hostettler.dev/dnc/dice"
+)
+
+// percentiles are the percentiles listed for and marked on distributions.
+var percentiles = []struct {
+	label string
+	q     float64
+}{{"P10", 0.1}, {"P25", 0.25}, {"Median", 0.5}, {"P75", 0.75}, {"P90", 0.9}}
+
+// renderHistograms charts histograms sharing their bars side by side, one
+// row per bar with the probability of each under its header. Cumulative adds
+// the chance of rolling at most the values of the bar and marks the
+// percentiles falling on it, it is meant for a single histogram.
+func renderHistograms(headers []string, hs []dice.Histogram, width int, cumulative bool) []string {
+	grid := hs[0]
+	values := make([]string, len(grid.Probs))
+	label := 0
+	for i := range values {
+		values[i] = barValues(grid, i)
+		label = max(label, len(values[i]))
+	}
+	markers := make([]string, len(grid.Probs))
+	extra := 0
+	if cumulative {
+		for _, p := range percentiles {
+			i := grid.Percentile(p.q)
+			markers[i] = strings.TrimSpace(markers[i] + " " + p.label)
+		}
+		extra = 8
+		for _, m := range markers {
+			if m != "" {
+				extra = max(extra, 8+3+len(m))
+			}
+		}
+	}
+	// Each cell is a bar followed by the probability, e.g. "  16.7%".
+	cell := max(1, (width-label-1-extra-3*(len(hs)-1))/len(hs)-8)
+	highest := 0.0
+	for _, h := range hs {
+		for _, p := range h.Probs {
+			highest = max(highest, p)
+		}
+	}
+
+	cells := make([]string, len(hs))
+	for i, h := range headers {
+		cells[i] = fmt.Sprintf("%-*s %7s", cell, h, "P")
+	}
+	head := fmt.Sprintf("%*s ", label, "") + strings.Join(cells, " │ ")
+	if cumulative {
+		head += fmt.Sprintf(" %7s", "P(≤)")
+	}
+	lines := []string{head}
+	total := 0.0
+	for i := range values {
+		for j, h := range hs {
+			cells[j] = fmt.Sprintf("%s %6.1f%%", bar(h.Probs[i], highest, cell), 100*h.Probs[i])
+		}
+		line := fmt.Sprintf("%*s ", label, values[i]) + strings.Join(cells, " │ ")
+		if cumulative {
+			total = min(total+grid.Probs[i], 1)
+			line += fmt.Sprintf(" %6.1f%%", 100*total)
+			if markers[i] != "" {
+				line += " ◂ " + markers[i]
+			}
+		}
+		lines = append(lines, line)
+	}
+	if grid.Width > 1 {
+		lines = append(lines, fmt.Sprintf("%d values per bar", grid.Width))
+	}
+	return lines
+}
+
+// queryHistograms queries the histograms of the expressions, given their
+// statistics, over their combined range so they share their bars. They keep
+// the statistics without probabilities if a query fails.
+func queryHistograms(exprs []string, stats []dice.Stats) ([]dice.Histogram, error) {
+	lo, hi := stats[0].Min, stats[0].Max
+	for _, s := range stats[1:] {
+		lo, hi = min(lo, s.Min), max(hi, s.Max)
+	}
+	hs := make([]dice.Histogram, len(stats))
+	for i, s := range stats {
+		hs[i].Stats = s
+	}
+	for i, expr := range exprs {
+		h, err := dice.NewHistogram(expr, stats[i], lo, hi)
+		if err != nil {
+			return hs, err
+		}
+		hs[i] = h
+	}
+	return hs, nil
+}
+
+// barValues names the values of bar i, e.g. "7" or "10-14".
+func barValues(h dice.Histogram, i int) string {
+	lo, hi := h.Bar(i)
+	if lo == hi {
+		return strconv.Itoa(lo)
+	}
+	return fmt.Sprintf("%d-%d", lo, hi)
+}
+
+// bar fills the width in proportion to the value, negative values are empty.
+func bar(value, highest float64, width int) string {
+	n := 0
+	if highest > 0 {
+		n = min(int(max(0, value)/highest*float64(width)), width)
+	}
+	return fmt.Sprintf("%-*s", width, strings.Repeat("█", n))
+}
@@ -0,0 +1,38 @@
+package quickaction
+
+import (
+	"strings"
+	"testing"
+
+	"hostettler.dev/dnc/dice"
+)
+
+func TestRenderHistograms(t *testing.T) {
+	// 1d2 + 1 and 2(1d2) + 1 from 2 to 5.
+	hit := dice.Histogram{Lo: 2, Width: 1, Probs: []float64{0.5, 0.5, 0, 0}}
+	crit := dice.Histogram{Lo: 2, Width: 1, Probs: []float64{0, 0.25, 0.5, 0.25}}
+	got := renderHistograms([]string{"Hit", "Crit"}, []dice.Histogram{hit, crit}, 40, false)
+	want := []string{
+		"  Hit             P │ Crit            P",
+		"2 █████████   50.0% │              0.0%",
+		"3 █████████   50.0% │ ████        25.0%",
+		"4              0.0% │ █████████   50.0%",
+		"5              0.0% │ ████        25.0%",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("renderHistograms() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// 1d4 grouped by two values.
+	d4 := dice.Histogram{Lo: 1, Width: 2, Probs: []float64{0.5, 0.5}}
+	got = renderHistograms([]string{""}, []dice.Histogram{d4}, 40, true)
+	want = []string{
+		"              P    P(≤)",
+		"1-2 ███   50.0%   50.0% ◂ P10 P25 Median",
+		"3-4 ███   50.0%  100.0% ◂ P75 P90",
+		"2 values per bar",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("renderHistograms() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
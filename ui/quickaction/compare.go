@@ -0,0 +1,85 @@
+package quickaction
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/dice"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/styles"
+)
+
+// CompareAction shows the distributions of two dice expressions side by side
+// in the reader, e.g. the damage with and without Great Weapon Master.
+type CompareAction struct{}
+
+func (a CompareAction) Name() string    { return "cmp" }
+func (a CompareAction) ArgHint() string { return "<exprA> | <exprB>" }
+func (a CompareAction) Access() Access  { return ReadsOnly }
+
+func (a CompareAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	sides := strings.Split(args, "|")
+	if len(sides) != 2 || strings.TrimSpace(sides[0]) == "" || strings.TrimSpace(sides[1]) == "" {
+		return ActionResult{ErrMsg: "usage: cmp <exprA> | <exprB>"}
+	}
+	var exprs, labels [2]string
+	var stats [2]dice.Stats
+	for i, side := range sides {
+		expr, label, err := resolveExpression(agg, strings.TrimSpace(side))
+		if err != nil {
+			return ActionResult{ErrMsg: err.Error()}
+		}
+		if stats[i], err = dice.Distribution(expr); err != nil {
+			return ActionResult{ErrMsg: fmt.Sprintf("%s: %s", label, err)}
+		}
+		exprs[i], labels[i] = expr, label
+	}
+	greater, equal, err := dice.Compare(exprs[0], exprs[1])
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	// The histograms take a query per bar, leave them to the command.
+	return ActionResult{Cmd: func() tea.Msg {
+		hs, err := queryHistograms(exprs[:], stats[:])
+		return command.LaunchReaderScreenMsg{Content: renderComparison(labels[0], labels[1], hs[0], hs[1], greater, equal, err)}
+	}}
+}
+
+// renderComparison lists the statistics and percentiles of both
+// distributions and the chance of one rolling higher than the other,
+// followed by the probability of each value charted side by side. The
+// percentiles and the chart are left out if querying the histograms failed.
+func renderComparison(labelA, labelB string, a, b dice.Histogram, greater, equal float64, err error) string {
+	separator := styles.MakeHorizontalSeparator(analysisWidth, 1)
+	row := func(label, valueA, valueB string) string {
+		return fmt.Sprintf("%-10s %12s %12s", label, valueA, valueB)
+	}
+	lines := []string{"A: " + labelA, "B: " + labelB, separator,
+		row("", "A", "B"),
+		row("Mean", approx(a.Stats)+fmt.Sprintf("%.2f", a.Mean), approx(b.Stats)+fmt.Sprintf("%.2f", b.Mean)),
+		row("Std", fmt.Sprintf("%.2f", a.StdDev), fmt.Sprintf("%.2f", b.StdDev)),
+		row("Min-max", fmt.Sprintf("%d-%d", a.Min, a.Max), fmt.Sprintf("%d-%d", b.Min, b.Max)),
+		row("Mode", strconv.Itoa(a.Mode), strconv.Itoa(b.Mode)),
+	}
+	if err == nil {
+		for _, p := range percentiles {
+			lines = append(lines, row(p.label, barValues(a, a.Percentile(p.q)), barValues(b, b.Percentile(p.q))))
+		}
+	}
+	lines = append(lines, "",
+		fmt.Sprintf("P(A > B) %.1f%%  P(A = B) %.1f%%  P(A < B) %.1f%%", 100*greater, 100*equal, 100*max(0, 1-greater-equal)),
+		separator)
+	if err != nil {
+		lines = append(lines, "Distributions: "+err.Error())
+	} else {
+		lines = append(lines, renderHistograms([]string{"A", "B"}, []dice.Histogram{a, b}, analysisWidth, false)...)
+	}
+	return styles.DefaultTextStyle.
+		Width(analysisWidth).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,48 @@
+package quickaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"hostettler.dev/dnc/dice"
+)
+
+func TestCompareAction(t *testing.T) {
+	agg := d20Agg()
+	for _, args := range []string{"", "2d6", "2d6 |", "| 1d12", "1d4 | 1d6 | 1d8"} {
+		assertErr(t, CompareAction{}.Execute(agg, args), "usage")
+	}
+	assertErr(t, CompareAction{}.Execute(agg, "1d8 + @luck | 1d8"), "unknown variable @luck")
+}
+
+func TestRenderComparison(t *testing.T) {
+	d4 := dice.Histogram{
+		Stats: dice.Stats{Mean: 2.5, StdDev: 1.12, Min: 1, Max: 4, Mode: 1, Median: 2},
+		Lo:    1, Width: 1, Probs: []float64{0.25, 0.25, 0.25, 0.25},
+	}
+	three := dice.Histogram{
+		Stats: dice.Stats{Mean: 3, Min: 3, Max: 3, Mode: 3, Median: 3},
+		Lo:    1, Width: 1, Probs: []float64{0, 0, 1, 0},
+	}
+	got := renderComparison("1d4", "3", d4, three, 0.25, 0.25, nil)
+	for _, want := range []string{
+		"A: 1d4",
+		"Mean               2.50         3.00",
+		"Min-max             1-4          3-3",
+		"P10                   1            3",
+		"Median                2            3",
+		"P90                   4            3",
+		"P(A > B) 25.0%  P(A = B) 25.0%  P(A < B) 50.0%",
+		"3 ████                 25.0% │ ██████████████████  100.0%",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in the comparison:\n%s", want, got)
+		}
+	}
+
+	got = renderComparison("1d4", "3", dice.Histogram{Stats: d4.Stats}, dice.Histogram{Stats: three.Stats}, 0.25, 0.25, errors.New("no dice"))
+	if !strings.Contains(got, "Distributions: no dice") || strings.Contains(got, "P10") {
+		t.Errorf("expected the error instead of the percentiles and chart:\n%s", got)
+	}
+}
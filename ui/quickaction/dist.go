@@ -0,0 +1,77 @@
+package quickaction
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/dice"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/styles"
+)
+
+// DistAction shows the distribution of a dice expression in the reader.
+type DistAction struct{}
+
+func (a DistAction) Name() string    { return "dist" }
+func (a DistAction) ArgHint() string { return "<expression|macro>" }
+func (a DistAction) Access() Access  { return ReadsOnly }
+
+func (a DistAction) Complete(agg *repository.CharacterAggregate, prefix string) []Completion {
+	return completeMacros(agg, prefix)
+}
+
+func (a DistAction) Execute(agg *repository.CharacterAggregate, args string) ActionResult {
+	args = strings.TrimSpace(args)
+	if args == "" {
+		return ActionResult{ErrMsg: "usage: dist <expression|macro>"}
+	}
+	expr, _, err := resolveExpression(agg, args)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	stats, err := dice.Distribution(expr)
+	if err != nil {
+		return ActionResult{ErrMsg: err.Error()}
+	}
+	label := args
+	if label != expr {
+		label += " (" + expr + ")"
+	}
+	notes := rollNotes(agg, expr)
+	// The histogram takes a query per bar, leave it to the command.
+	return ActionResult{Cmd: func() tea.Msg {
+		hs, err := queryHistograms([]string{expr}, []dice.Stats{stats})
+		return command.LaunchReaderScreenMsg{Content: renderDistribution(label, hs[0], notes, err)}
+	}}
+}
+
+// renderDistribution shows the statistics and percentiles of a distribution
+// followed by one bar per value with the probability of rolling it and of
+// rolling at most it. Percentiles are marked on the values they fall on. The
+// percentiles and the chart are left out if querying the histogram failed.
+func renderDistribution(label string, h dice.Histogram, notes []string, err error) string {
+	separator := styles.MakeHorizontalSeparator(analysisWidth, 1)
+	lines := []string{label, separator,
+		fmt.Sprintf("Mean %s%.2f  Std %.2f  Min %d  Max %d  Mode %d", approx(h.Stats), h.Mean, h.StdDev, h.Min, h.Max, h.Mode)}
+	if err == nil {
+		var values []string
+		for _, p := range percentiles {
+			values = append(values, p.label+" "+barValues(h, h.Percentile(p.q)))
+		}
+		lines = append(lines, strings.Join(values, "  "))
+	}
+	lines = append(lines, notes...)
+	lines = append(lines, separator)
+	if err != nil {
+		lines = append(lines, "Distribution: "+err.Error())
+	} else {
+		lines = append(lines, renderHistograms([]string{""}, []dice.Histogram{h}, analysisWidth, true)...)
+	}
+	return styles.DefaultTextStyle.
+		Width(analysisWidth).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
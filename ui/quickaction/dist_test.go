@@ -0,0 +1,41 @@
+package quickaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"hostettler.dev/dnc/dice"
+)
+
+func TestRenderDistribution(t *testing.T) {
+	// 2d2
+	h := dice.Histogram{
+		Stats: dice.Stats{Mean: 3, StdDev: 0.71, Min: 2, Max: 4, Mode: 3, Median: 3},
+		Lo:    2, Width: 1, Probs: []float64{0.25, 0.5, 0.25},
+	}
+	got := renderDistribution("2d2", h, []string{"Poisoned: disadvantage on attack rolls"}, nil)
+	for _, want := range []string{
+		"Mean 3.00  Std 0.71  Min 2  Max 4  Mode 3",
+		"P10 2  P25 2  Median 3  P75 3  P90 4",
+		"Poisoned: disadvantage on attack rolls",
+		"P    P(≤)",
+		"   25.0%   25.0% ◂ P10 P25",
+		"   50.0%   75.0% ◂ Median P75",
+		"   25.0%  100.0% ◂ P90",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in the distribution:\n%s", want, got)
+		}
+	}
+
+	got = renderDistribution("2d2", dice.Histogram{Stats: h.Stats}, nil, errors.New("no dice"))
+	if !strings.Contains(got, "Distribution: no dice") || strings.Contains(got, "P10") {
+		t.Errorf("expected the error instead of the percentiles and chart:\n%s", got)
+	}
+}
+
+func TestDistAction(t *testing.T) {
+	assertErr(t, DistAction{}.Execute(d20Agg(), " "), "usage")
+	assertErr(t, DistAction{}.Execute(d20Agg(), "1d8 + @luck"), "unknown variable @luck")
+}
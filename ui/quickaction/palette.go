@@ -7,6 +7,7 @@ import (
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"hostettler.dev/dnc/models"
 	"hostettler.dev/dnc/repository"
 	"hostettler.dev/dnc/ui/styles"
 	"hostettler.dev/dnc/util"
@@ -19,10 +20,14 @@ type Palette struct {
 	registry    *Registry
 	input       textinput.Model
 	suggestions []Action
+	// completions replace the suggestions once the argument of a Completer
+	// is typed.
+	completions []Completion
 	cursor      int
 	active      bool
 	errMsg      string
 	resultMsg   string
+	readOnly    bool
 	agg         *repository.CharacterAggregate
 }
 
@@ -42,6 +47,25 @@ func (p *Palette) SetCharacter(agg *repository.CharacterAggregate) {
 	p.agg = agg
 }
 
+// SetReadOnly hides and blocks actions that modify the character, rolls are
+// not logged.
+func (p *Palette) SetReadOnly(readOnly bool) {
+	p.readOnly = readOnly
+}
+
+func (p *Palette) available(actions []Action) []Action {
+	if !p.readOnly {
+		return actions
+	}
+	var out []Action
+	for _, a := range actions {
+		if accessOf(a) != Modifies {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
 func (p *Palette) Active() bool {
 	return p.active
 }
@@ -53,7 +77,18 @@ func (p *Palette) Open() {
 	p.errMsg = ""
 	p.resultMsg = ""
 	p.cursor = 0
-	p.suggestions = p.registry.All()
+	p.suggestions = p.available(p.registry.All())
+	p.completions = nil
+}
+
+// Run opens the palette and executes the input, leaving the result or error
+// shown.
+func (p *Palette) Run(input string) tea.Cmd {
+	p.Open()
+	p.input.SetValue(input)
+	p.input.CursorEnd()
+	p.updateSuggestions()
+	return p.execute()
 }
 
 func (p *Palette) Close() {
@@ -76,7 +111,7 @@ func (p *Palette) Update(msg tea.Msg) tea.Cmd {
 			}
 			return nil
 		case key.Matches(msg, p.keymap.Down):
-			if p.cursor < len(p.suggestions)-1 {
+			if p.cursor < p.options()-1 {
 				p.cursor++
 			}
 			return nil
@@ -93,36 +128,99 @@ func (p *Palette) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// RollAttackMsg rolls an attack in the palette, e.g. from its row.
+type RollAttackMsg struct {
+	Attack *models.AttackTO
+}
+
+func RollAttackCmd(attack *models.AttackTO) func() tea.Msg {
+	return func() tea.Msg {
+		return RollAttackMsg{attack}
+	}
+}
+
+// RollAttack rolls the attack like "attack <name>" would, even if another
+// attack has the same name.
+func (p *Palette) RollAttack(attack *models.AttackTO) tea.Cmd {
+	p.Open()
+	p.input.SetValue("attack " + attack.Name)
+	p.input.CursorEnd()
+	p.updateSuggestions()
+	action, _, _ := p.registry.Parse("attack")
+	a, ok := action.(AttackAction)
+	if !ok {
+		p.errMsg = "unknown action"
+		return nil
+	}
+	return p.run(a, func(agg *repository.CharacterAggregate) ActionResult {
+		return a.rollAttack(agg, attack, 0, models.NoEffect)
+	})
+}
+
 func (p *Palette) execute() tea.Cmd {
 	action, args, found := p.registry.Parse(p.input.Value())
 	if !found {
 		p.errMsg = "unknown action"
 		return nil
 	}
+	return p.run(action, func(agg *repository.CharacterAggregate) ActionResult {
+		return action.Execute(agg, args)
+	})
+}
+
+// run executes the action on the character, or on a copy of it if rolls must
+// not be logged.
+func (p *Palette) run(action Action, execute func(*repository.CharacterAggregate) ActionResult) tea.Cmd {
 	if p.agg == nil {
 		p.errMsg = "no character loaded"
 		return nil
 	}
-	res := action.Execute(p.agg, args)
+	agg := p.agg
+	if p.readOnly {
+		switch accessOf(action) {
+		case Modifies:
+			p.errMsg = "read-only: " + action.Name() + " is disabled"
+			return nil
+		case LogsRolls:
+			// Roll on a copy so the roll log is left as is.
+			agg = p.agg.Clone()
+		}
+	}
+	res := execute(agg)
+	if agg != p.agg {
+		// Only the copy changed, there is nothing to write back.
+		res.Cmd = nil
+	}
 	if res.ErrMsg != "" {
 		p.errMsg = res.ErrMsg
 		return nil
 	}
 	if res.Result != "" {
 		p.resultMsg = res.Result
-		return nil
+		if res.Suggest != "" {
+			p.input.SetValue(res.Suggest)
+			p.input.CursorEnd()
+			p.updateSuggestions()
+		}
+		return res.Cmd
 	}
 	p.Close()
 	return res.Cmd
 }
 
 func (p *Palette) autocomplete() {
-	if len(p.suggestions) == 0 {
+	if p.options() == 0 {
 		return
 	}
-	if p.cursor >= len(p.suggestions) {
+	if p.cursor >= p.options() {
 		p.cursor = 0
 	}
+	if len(p.completions) > 0 {
+		action, _, _ := p.registry.Parse(p.input.Value())
+		p.input.SetValue(action.Name() + " " + p.completions[p.cursor].Value)
+		p.input.CursorEnd()
+		return
+	}
 	selected := p.suggestions[p.cursor]
 	value := selected.Name()
 	if selected.ArgHint() != "" {
@@ -135,10 +233,25 @@ func (p *Palette) autocomplete() {
 func (p *Palette) updateSuggestions() {
 	val := p.input.Value()
 	parts := strings.SplitN(val, " ", 2)
-	p.suggestions = p.registry.Match(parts[0])
-	if p.cursor >= len(p.suggestions) {
-		p.cursor = max(0, len(p.suggestions)-1)
+	p.suggestions = p.available(p.registry.Match(parts[0]))
+	p.completions = nil
+	if len(parts) == 2 && p.agg != nil {
+		action, args, _ := p.registry.Parse(val)
+		if c, ok := action.(Completer); ok && len(p.available([]Action{action})) > 0 {
+			p.completions = c.Complete(p.agg, args)
+		}
+	}
+	if len(p.completions) > 0 {
+		p.suggestions = nil
 	}
+	if p.cursor >= p.options() {
+		p.cursor = max(0, p.options()-1)
+	}
+}
+
+// options is the number of suggestions or completions shown.
+func (p *Palette) options() int {
+	return len(p.suggestions) + len(p.completions)
 }
 
 var (
@@ -166,6 +279,14 @@ func (p *Palette) View() string {
 			lines = append(lines, suggestionStyle.Render("  "+s.Name())+hintLabel(s))
 		}
 	}
+	for i, c := range p.completions {
+		hint := " " + hintStyle.Render(c.Hint)
+		if i == p.cursor {
+			lines = append(lines, selectedStyle.Render("▸ "+c.Value)+hint)
+		} else {
+			lines = append(lines, suggestionStyle.Render("  "+c.Value)+hint)
+		}
+	}
 
 	if p.resultMsg != "" {
 		lines = append(lines, resultStyle.Render(p.resultMsg))
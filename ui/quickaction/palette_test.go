@@ -0,0 +1,157 @@
+package quickaction
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/util"
+)
+
+func TestReadOnlyPaletteBlocksModifyingActions(t *testing.T) {
+	p := NewPalette(util.DefaultKeyMap(), NewRegistry())
+	p.SetReadOnly(true)
+	agg := charAgg(10, 20, []int{0, 2}, []int{0, 0})
+	p.SetCharacter(agg)
+	p.Open()
+
+	for _, s := range p.suggestions {
+		if accessOf(s) == Modifies {
+			t.Errorf("expected %s to be hidden in read-only mode", s.Name())
+		}
+	}
+
+	p.input.SetValue("dmg 5")
+	if cmd := p.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Errorf("expected no Cmd for a blocked action")
+	}
+	if !strings.Contains(p.errMsg, "read-only") {
+		t.Errorf("expected a read-only error, got %q", p.errMsg)
+	}
+	if agg.Character.CurrHitPoints != 10 {
+		t.Errorf("expected HP to be unchanged, got %d", agg.Character.CurrHitPoints)
+	}
+}
+
+func TestReadOnlyPaletteRollsWithoutLogging(t *testing.T) {
+	p := NewPalette(util.DefaultKeyMap(), NewRegistry())
+	p.SetReadOnly(true)
+	agg := d20Agg()
+	p.SetCharacter(agg)
+
+	if cmd := p.Run("check stealth"); cmd != nil {
+		t.Error("expected no write-back in read-only mode")
+	}
+	if !strings.HasPrefix(p.resultMsg, "Stealth check") {
+		t.Errorf("expected the check to be rolled, got result %q err %q", p.resultMsg, p.errMsg)
+	}
+	if len(agg.Rolls) != 0 {
+		t.Errorf("expected the roll log to be unchanged, got %d rolls", len(agg.Rolls))
+	}
+}
+
+func TestPaletteSuggestsFollowUp(t *testing.T) {
+	p := NewPalette(util.DefaultKeyMap(), NewRegistry())
+	agg := charAgg(30, 30, nil, nil)
+	agg.Character.Concentration = 1
+	p.SetCharacter(agg)
+	p.Open()
+
+	p.input.SetValue("dmg 30")
+	if cmd := p.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd == nil {
+		t.Error("expected the write-back Cmd with the result")
+	}
+	if p.resultMsg != "concentration on a spell ended" {
+		t.Errorf("result = %q", p.resultMsg)
+	}
+
+	agg.Character.CurrHitPoints = 30
+	agg.Character.Concentration = 1
+	p.input.SetValue("dmg 24")
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if p.input.Value() != "consave 12" {
+		t.Errorf("expected the save to be offered, got input %q", p.input.Value())
+	}
+}
+
+func TestPaletteCompletesMacros(t *testing.T) {
+	p := NewPalette(util.DefaultKeyMap(), NewRegistry())
+	agg := charAgg(10, 20, nil, nil)
+	agg.Macros = []models.MacroTO{{Name: "smite", Expression: "2d8 + 1d8"}, {Name: "sneak", Expression: "3d6"}}
+	p.SetCharacter(agg)
+	p.Open()
+
+	p.input.SetValue("roll s")
+	p.updateSuggestions()
+	if len(p.completions) != 2 || len(p.suggestions) != 0 {
+		t.Fatalf("expected both macros instead of actions, got %v and %d actions", p.completions, len(p.suggestions))
+	}
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if p.input.Value() != "roll sneak" {
+		t.Errorf("expected the macro to be completed, got input %q", p.input.Value())
+	}
+
+	p.SetReadOnly(true)
+	p.input.SetValue("ev s")
+	p.updateSuggestions()
+	if len(p.completions) != 2 {
+		t.Errorf("expected ev to complete in read-only mode, got %v", p.completions)
+	}
+}
+
+func TestPaletteWritesBackWithResult(t *testing.T) {
+	p := NewPalette(util.DefaultKeyMap(), NewRegistry())
+	agg := charAgg(1, 30, nil, nil)
+	agg.Classes = []models.CharacterClassTO{{ClassName: "Fighter", Level: 2, HitDie: 10}}
+	p.SetCharacter(agg)
+
+	cmd := p.Run("shortrest 1")
+	if !strings.Contains(p.resultMsg, "healed") {
+		t.Fatalf("expected the healing to be shown, got result %q err %q", p.resultMsg, p.errMsg)
+	}
+	if cmd == nil {
+		t.Fatal("expected the write-back Cmd along with the result")
+	}
+	if _, ok := cmd().(command.WriteBackRequestMsg); !ok {
+		t.Errorf("expected a write-back request, got %T", cmd())
+	}
+}
+
+func TestPaletteRun(t *testing.T) {
+	p := NewPalette(util.DefaultKeyMap(), NewRegistry())
+	p.SetCharacter(charAgg(10, 20, nil, nil))
+
+	if cmd := p.Run("heal 5"); cmd == nil {
+		t.Error("expected the write-back Cmd")
+	}
+	if p.Active() {
+		t.Error("expected the palette to close without a result")
+	}
+
+	p.Run("check arcana")
+	if !p.Active() || !strings.Contains(p.errMsg, "no skill named") {
+		t.Errorf("expected the error to be shown, got active=%t err=%q", p.Active(), p.errMsg)
+	}
+}
+
+func TestPaletteRollAttack(t *testing.T) {
+	r := &Registry{}
+	r.Register(AttackAction{Roll: sequence(10, 4)})
+	p := NewPalette(util.DefaultKeyMap(), r)
+	agg := d20Agg()
+	agg.Attacks = []models.AttackTO{
+		{Name: "Dagger adv", Bonus: 2, Damage: "1d4"},
+		{Name: "Dagger adv", Bonus: 7, Damage: "1d4 + 3"},
+	}
+	p.SetCharacter(agg)
+
+	if cmd := p.RollAttack(&agg.Attacks[1]); cmd == nil {
+		t.Error("expected the write-back Cmd")
+	}
+	if want := "Dagger adv 17 to hit (1d20: 10, +7)\n7 damage (1d4: 4)"; p.resultMsg != want {
+		t.Errorf("resultMsg = %q, want %q", p.resultMsg, want)
+	}
+}
@@ -10,13 +10,25 @@ func NewRegistry() *Registry {
 	r := &Registry{}
 	r.Register(QuitAction{})
 	r.Register(LongRestAction{})
+	r.Register(ShortRestAction{})
 	r.Register(CastAction{})
 	r.Register(HealAction{})
 	r.Register(DmgAction{})
+	r.Register(ConSaveAction{})
+	r.Register(CheckAction{})
+	r.Register(SaveAction{})
+	r.Register(AttackAction{})
+	r.Register(AnalyzeAction{})
 	r.Register(TempHPAction{})
+	r.Register(AttuneAction{})
+	r.Register(LogAction{})
+	r.Register(RollAction{})
+	r.Register(RollsAction{})
+	r.Register(MacrosAction{})
 	r.Register(ProbAction{})
 	r.Register(EvAction{})
 	r.Register(DistAction{})
+	r.Register(CompareAction{})
 	return r
 }
 
@@ -0,0 +1,149 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/google/uuid"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/editor"
+	"hostettler.dev/dnc/ui/list"
+	"hostettler.dev/dnc/ui/styles"
+	"hostettler.dev/dnc/util"
+)
+
+var (
+	classScreenHeight = 16
+	classListWidth    = styles.SmallScreenWidth - 6
+)
+
+// ClassScreen lists the classes of a multiclassed character and their
+// levels, hit dice and spellcasting progression.
+type ClassScreen struct {
+	keymap    util.KeyMap
+	character *repository.CharacterAggregate
+	FocusManager
+
+	classList *list.List
+
+	classRows *Collection[models.CharacterClassTO]
+}
+
+func NewClassScreen(k util.KeyMap, c *repository.CharacterAggregate) *ClassScreen {
+	s := &ClassScreen{
+		keymap:    k,
+		character: c,
+		classList: list.NewList(k, list.LeftAlignedListStyle).
+			WithTitle("Classes").
+			WithFixedWidth(classListWidth).
+			WithViewport(classScreenHeight - 6),
+	}
+	s.classRows = NewCollection(k, s.classList,
+		func() []*models.CharacterClassTO { return util.Pointers(s.character.Classes) },
+		func(cl *models.CharacterClassTO) uuid.UUID { return cl.ID },
+		s.character.AddEmptyClass,
+		s.character.DeleteClass,
+		func(cl *models.CharacterClassTO) *list.StructRow[models.CharacterClassTO] {
+			return list.NewStructRow(s.keymap, cl, renderClassRow, createClassEditors(s.keymap, cl))
+		},
+	)
+	return s
+}
+
+func (s *ClassScreen) Init() tea.Cmd {
+	s.classRows.Repopulate()
+	s.Wire(FocusGraph{s.classList: {}}, s.classList)
+	return nil
+}
+
+// Focus rebuilds the rows, classes may have been edited elsewhere.
+func (s *ClassScreen) Focus() {
+	s.classRows.Repopulate()
+	s.FocusManager.Focus()
+}
+
+func (s *ClassScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if key.Matches(msg, s.keymap.Escape) && !util.IsLetterKey(msg) {
+			return s, command.SwitchToPrevScreenCmd
+		}
+		_, cmd = s.classList.Update(msg)
+	}
+	return s, cmd
+}
+
+func (s *ClassScreen) View() tea.View {
+	total := styles.GrayTextStyle.Render(fmt.Sprintf("Character level: %d", s.character.TotalLevel()))
+	separator := styles.MakeHorizontalSeparator(classListWidth, 1)
+	return tea.NewView(styles.DefaultBorderStyle.
+		Width(styles.SmallScreenWidth).
+		Height(classScreenHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, s.classList.View().Content, separator, total)))
+}
+
+func createClassEditors(k util.KeyMap, cl *models.CharacterClassTO) []editor.ValueEditor {
+	return []editor.ValueEditor{
+		editor.NewStringEditor(k, "Class", &cl.ClassName),
+		editor.NewStringEditor(k, "Subclass", &cl.Subclass),
+		editor.NewIntEditor(k, "Level", &cl.Level),
+		editor.NewEnumEditor(k, styles.HitDieStrings, "Hit Die", &cl.HitDie),
+		editor.NewIntEditor(k, "Hit Dice Used", &cl.HitDiceUsed),
+		editor.NewEnumEditor(k, styles.SpellcastingStrings, "Spellcasting", &cl.Spellcasting),
+		editor.NewEnumEditor(k, styles.AbilityStrings, "Spellcasting Ability (None = character's)", &cl.SpellcastingAbility),
+	}
+}
+
+func renderClassRow(cl *models.CharacterClassTO) string {
+	return fmt.Sprintf("%-14s %-16s %2d  d%-2d %s", cl.ClassName, cl.Subclass, cl.Level, cl.HitDie,
+		spellcastingLabel(cl.Spellcasting))
+}
+
+func spellcastingLabel(v int) string {
+	for _, m := range styles.SpellcastingStrings {
+		if m.Value == v && v != int(models.NoSpellcasting) {
+			return m.Label
+		}
+	}
+	return ""
+}
+
+// NewClassLevelsRow shows the class summary, editing opens the ClassScreen.
+func NewClassLevelsRow(k util.KeyMap, agg *repository.CharacterAggregate, labelWidth int) *list.StructRow[repository.CharacterAggregate] {
+	return list.NewStructRow(k, agg,
+		func(a *repository.CharacterAggregate) string {
+			return styles.RenderLeftBound(labelWidth, "Levels:", a.ClassLevels())
+		}, nil).
+		WithEditAction(func(*repository.CharacterAggregate) tea.Cmd {
+			return command.SwitchScreenCmd(command.ClassScreenIndex)
+		}).
+		WithReader(renderClassBreakdown)
+}
+
+func renderClassBreakdown(a *repository.CharacterAggregate) string {
+	lines := []string{fmt.Sprintf("Character level %d", a.TotalLevel()),
+		styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)}
+	for _, cl := range a.Classes {
+		line := fmt.Sprintf("%s %d", cl.ClassName, cl.Level)
+		if cl.Subclass != "" {
+			line += " (" + cl.Subclass + ")"
+		}
+		line += fmt.Sprintf(", d%d hit die", cl.HitDie)
+		if label := spellcastingLabel(cl.Spellcasting); label != "" {
+			dc, attack := a.ClassSpellcasting(&cl)
+			line += fmt.Sprintf(", %s (%s, DC %d, %s)", strings.ToLower(label),
+				abilityLabel(int(a.ClassSpellcastingAbility(&cl))), dc, styles.WithSign(attack))
+		}
+		lines = append(lines, line)
+	}
+	return styles.DefaultTextStyle.
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
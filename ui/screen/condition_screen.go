@@ -0,0 +1,178 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/google/uuid"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/editor"
+	"hostettler.dev/dnc/ui/list"
+	"hostettler.dev/dnc/ui/styles"
+	"hostettler.dev/dnc/util"
+)
+
+var (
+	conditionScreenHeight = 16
+	conditionListWidth    = styles.SmallScreenWidth - 6
+)
+
+// ConditionScreen lists the conditions of a character. The header toggles
+// the known conditions, custom conditions are added below.
+type ConditionScreen struct {
+	keymap    util.KeyMap
+	character *repository.CharacterAggregate
+	FocusManager
+
+	conditionList *list.List
+
+	conditionRows *Collection[models.ConditionTO]
+}
+
+func NewConditionScreen(k util.KeyMap, c *repository.CharacterAggregate) *ConditionScreen {
+	s := &ConditionScreen{
+		keymap:    k,
+		character: c,
+		conditionList: list.NewList(k, list.LeftAlignedListStyle).
+			WithTitle("Conditions").
+			WithFixedWidth(conditionListWidth).
+			WithViewport(conditionScreenHeight - 6).
+			WithSectionStyle(list.SectionStyle{HeaderSeparator: "─"}),
+	}
+	s.conditionRows = NewCollection(k, s.conditionList,
+		func() []*models.ConditionTO { return util.Pointers(s.character.Conditions) },
+		func(cd *models.ConditionTO) uuid.UUID { return cd.ID },
+		s.character.AddEmptyCondition,
+		s.character.DeleteCondition,
+		func(cd *models.ConditionTO) *list.StructRow[models.ConditionTO] {
+			return list.NewStructRow(s.keymap, cd, renderConditionRow, createConditionEditors(s.keymap, cd)).
+				WithReader(renderConditionRules)
+		},
+	).WithOnChange(s.populateConditions)
+	return s
+}
+
+func (s *ConditionScreen) Init() tea.Cmd {
+	s.populateConditions()
+	s.Wire(FocusGraph{s.conditionList: {}}, s.conditionList)
+	return nil
+}
+
+// Focus rebuilds the rows, conditions may have been toggled elsewhere.
+func (s *ConditionScreen) Focus() {
+	s.populateConditions()
+	s.FocusManager.Focus()
+}
+
+func (s *ConditionScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if key.Matches(msg, s.keymap.Escape) && !util.IsLetterKey(msg) {
+			return s, command.SwitchToPrevScreenCmd
+		}
+		_, cmd = s.conditionList.Update(msg)
+	}
+	return s, cmd
+}
+
+func (s *ConditionScreen) View() tea.View {
+	hint := styles.GrayTextStyle.Render("Edit the first row to toggle known conditions.")
+	separator := styles.MakeHorizontalSeparator(conditionListWidth, 1)
+	return tea.NewView(styles.DefaultBorderStyle.
+		Width(styles.SmallScreenWidth).
+		Height(conditionScreenHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, s.conditionList.View().Content, separator, hint)))
+}
+
+func (s *ConditionScreen) populateConditions() {
+	sec := s.conditionRows.Section()
+	sec.Header = newConditionToggleRow(s.keymap, s.character)
+	s.conditionList.WithSections([]list.Section{sec})
+}
+
+// newConditionToggleRow selects the known conditions in one editor.
+func newConditionToggleRow(k util.KeyMap, agg *repository.CharacterAggregate) *list.StructRow[repository.CharacterAggregate] {
+	return list.NewStructRow(k, agg,
+		func(a *repository.CharacterAggregate) string {
+			return fmt.Sprintf("Known conditions (%d)", len(a.ActiveConditions()))
+		},
+		[]editor.ValueEditor{
+			editor.NewMultiEnumEditor(k, styles.ConditionStrings[1:], "Conditions", agg.ActiveConditions, agg.SetConditions),
+		})
+}
+
+func createConditionEditors(k util.KeyMap, cd *models.ConditionTO) []editor.ValueEditor {
+	return []editor.ValueEditor{
+		editor.NewEnumEditor(k, styles.ConditionStrings, "Condition", &cd.Condition),
+		editor.NewStringEditor(k, "Name (Custom)", &cd.Name),
+		editor.NewStringEditor(k, "Duration", &cd.Duration),
+	}
+}
+
+func renderConditionRow(cd *models.ConditionTO) string {
+	return fmt.Sprintf("%-20s %s", cd.Label(), cd.Duration)
+}
+
+func renderConditionRules(cd *models.ConditionTO) string {
+	lines := []string{cd.Label(), styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)}
+	if cd.Duration != "" {
+		lines = append(lines, "Duration: "+cd.Duration, "")
+	}
+	if summary := models.Condition(cd.Condition).Summary(); summary != "" {
+		lines = append(lines, summary)
+	} else {
+		lines = append(lines, styles.GrayTextStyle.Render("Custom condition, no rules summary."))
+	}
+	return styles.DefaultTextStyle.
+		Width(styles.SmallScreenWidth - 4).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
+
+// NewConditionsRow shows the conditions of the character, editing opens the
+// ConditionScreen.
+func NewConditionsRow(k util.KeyMap, agg *repository.CharacterAggregate, labelWidth, valueWidth int) *list.StructRow[repository.CharacterAggregate] {
+	return list.NewStructRow(k, agg,
+		func(a *repository.CharacterAggregate) string {
+			labels := []rune(a.ConditionLabels())
+			if len(labels) > valueWidth {
+				labels = append(labels[:max(0, valueWidth-1)], '…')
+			}
+			return styles.RenderLeftBound(labelWidth, "Condition:", string(labels))
+		}, nil).
+		WithEditAction(func(*repository.CharacterAggregate) tea.Cmd {
+			return command.SwitchScreenCmd(command.ConditionScreenIndex)
+		}).
+		WithReader(renderAllConditionRules)
+}
+
+func renderAllConditionRules(a *repository.CharacterAggregate) string {
+	lines := []string{"Conditions", styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)}
+	if len(a.Conditions) == 0 && a.Character.Exhaustion == 0 {
+		lines = append(lines, styles.GrayTextStyle.Render("No conditions."))
+	}
+	for _, cd := range a.Conditions {
+		line := cd.Label()
+		if cd.Duration != "" {
+			line += " (" + cd.Duration + ")"
+		}
+		if summary := models.Condition(cd.Condition).Summary(); summary != "" {
+			line += ": " + summary
+		}
+		lines = append(lines, line)
+	}
+	if effects := a.ExhaustionEffects(); len(effects) > 0 {
+		lines = append(lines, fmt.Sprintf("Exhaustion %d: %s", a.Character.Exhaustion, strings.Join(effects, ", ")))
+	}
+	return styles.DefaultTextStyle.
+		Width(styles.SmallScreenWidth - 4).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,184 @@
+package screen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/editor"
+	"hostettler.dev/dnc/ui/list"
+	"hostettler.dev/dnc/ui/styles"
+	"hostettler.dev/dnc/util"
+)
+
+// DerivedInfo is a value computed from the rest of the sheet that can be
+// overridden manually.
+type DerivedInfo struct {
+	label    string
+	override *models.OptionalInt
+	derived  func() int
+	format   func(int) string
+	// notes are extra lines for the reader, e.g. a per-class breakdown.
+	notes func() []string
+}
+
+func (d *DerivedInfo) value() int {
+	return d.override.Or(d.derived())
+}
+
+func (d *DerivedInfo) isDerived() bool {
+	return !d.override.Valid
+}
+
+// newDerivedRow renders the value with a marker when it is derived. Extra
+// editors come after the override, e.g. for bonuses feeding the derived value.
+func newDerivedRow(k util.KeyMap, d *DerivedInfo, render func(*DerivedInfo) string, extra ...editor.ValueEditor) *list.StructRow[DerivedInfo] {
+	editors := append([]editor.ValueEditor{
+		editor.NewOptionalIntEditor(k, d.label+" (empty = auto)", d.override),
+	}, extra...)
+	return list.NewStructRow(k, d, func(d *DerivedInfo) string {
+		return render(d) + styles.DerivedMarker(d.isDerived())
+	}, editors).WithReader(renderDerivedInfo)
+}
+
+func renderDerivedInfo(d *DerivedInfo) string {
+	override := "none"
+	if !d.isDerived() {
+		override = d.format(d.override.Int)
+	}
+	lines := []string{
+		d.label,
+		styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1),
+		"Derived:  " + d.format(d.derived()),
+		"Override: " + override,
+	}
+	if d.notes != nil {
+		if notes := d.notes(); len(notes) > 0 {
+			lines = append(append(lines, ""), notes...)
+		}
+	}
+	lines = append(lines, "",
+		styles.GrayTextStyle.Render("Values marked with * are derived. Clear the override to derive it again."))
+	content := strings.Join(lines, "\n")
+	return styles.DefaultTextStyle.
+		Width(styles.SmallScreenWidth - 4).
+		AlignHorizontal(lipgloss.Left).
+		Render(content)
+}
+
+// NewProficiencyBonusRow shows the proficiency bonus, derived from the
+// character level.
+func NewProficiencyBonusRow(k util.KeyMap, agg *repository.CharacterAggregate, labelWidth int) *list.StructRow[DerivedInfo] {
+	d := &DerivedInfo{
+		label:    "Proficiency Bonus",
+		override: &agg.Character.ProficiencyBonus,
+		derived:  agg.DerivedProficiencyBonus,
+		format:   styles.WithSign,
+	}
+	return newDerivedRow(k, d, func(d *DerivedInfo) string {
+		return styles.RenderLeftBound(labelWidth, d.label+":", d.format(d.value()))
+	})
+}
+
+// newArmorClassRow derives the AC from the equipped items, the reader shows
+// the breakdown.
+func newArmorClassRow(k util.KeyMap, agg *repository.CharacterAggregate) *list.StructRow[DerivedInfo] {
+	d := &DerivedInfo{
+		label:    "AC",
+		override: &agg.Character.ArmorClass,
+		derived:  agg.DerivedArmorClass,
+		format:   strconv.Itoa,
+		notes: func() []string {
+			notes := []string{}
+			for i, p := range agg.ArmorClassBreakdown() {
+				value := styles.WithSign(p.Value)
+				if i == 0 {
+					value = strconv.Itoa(p.Value)
+				}
+				notes = append(notes, styles.RenderEdgeBound(30, 4, p.Source, value))
+			}
+			return notes
+		},
+	}
+	return newDerivedRow(k, d, func(d *DerivedInfo) string {
+		return styles.RenderEdgeBound(statColWidth, statTinyColWidth, d.label, d.format(d.value()))
+	}, editor.NewEnumEditor(k, styles.UnarmoredDefenseStrings, "Unarmored Defense", &agg.Character.UnarmoredDefense))
+}
+
+func newInitiativeRow(k util.KeyMap, agg *repository.CharacterAggregate) *list.StructRow[DerivedInfo] {
+	d := &DerivedInfo{
+		label:    "Initiative",
+		override: &agg.Character.Initiative,
+		derived:  agg.DerivedInitiative,
+		format:   styles.WithSign,
+	}
+	return newDerivedRow(k, d, func(d *DerivedInfo) string {
+		return styles.RenderEdgeBound(statColWidth, statTinyColWidth, d.label, d.format(d.value()))
+	}, editor.NewIntEditor(k, "Initiative Bonus", &agg.Character.InitiativeBonus))
+}
+
+func newPassiveScoreRow(k util.KeyMap, agg *repository.CharacterAggregate, skill string) *list.StructRow[DerivedInfo] {
+	d := &DerivedInfo{
+		label:    "Passive " + skill,
+		override: agg.PassiveOverride(skill),
+		derived:  func() int { return agg.DerivedPassiveScore(skill) },
+		format:   strconv.Itoa,
+	}
+	return newDerivedRow(k, d, func(d *DerivedInfo) string {
+		return styles.RenderEdgeBound(statLongColWidth, statTinyColWidth-1, "  "+skill, d.format(d.value()))
+	})
+}
+
+func newSpellSaveDCRow(k util.KeyMap, agg *repository.CharacterAggregate) *list.StructRow[DerivedInfo] {
+	d := &DerivedInfo{
+		label:    "Spell Save DC",
+		override: &agg.Character.SpellSaveDC,
+		derived:  agg.DerivedSpellSaveDC,
+		format:   strconv.Itoa,
+		notes:    func() []string { return classSpellcastingNotes(agg) },
+	}
+	return newDerivedRow(k, d, func(d *DerivedInfo) string {
+		return d.label + ": " + d.format(d.value())
+	}, editor.NewIntEditor(k, "Spell Save DC Item Bonus", &agg.Character.SpellSaveDCItemBonus))
+}
+
+func newSpellAttackBonusRow(k util.KeyMap, agg *repository.CharacterAggregate) *list.StructRow[DerivedInfo] {
+	d := &DerivedInfo{
+		label:    "Spell Attack Bonus",
+		override: &agg.Character.SpellAttackBonus,
+		derived:  agg.DerivedSpellAttackBonus,
+		format:   styles.WithSign,
+		notes:    func() []string { return classSpellcastingNotes(agg) },
+	}
+	return newDerivedRow(k, d, func(d *DerivedInfo) string {
+		return d.label + ": " + d.format(d.value())
+	}, editor.NewIntEditor(k, "Spell Attack Item Bonus", &agg.Character.SpellAttackItemBonus))
+}
+
+// classSpellcastingNotes lists the DC and attack bonus of every spellcasting
+// class, they differ when multiclassing into classes with other abilities.
+func classSpellcastingNotes(agg *repository.CharacterAggregate) []string {
+	notes := []string{}
+	for i := range agg.Classes {
+		cl := &agg.Classes[i]
+		if cl.Spellcasting == int(models.NoSpellcasting) {
+			continue
+		}
+		dc, attack := agg.ClassSpellcasting(cl)
+		notes = append(notes, fmt.Sprintf("%s (%s): DC %d, %s to hit", cl.ClassName,
+			abilityLabel(int(agg.ClassSpellcastingAbility(cl))), dc, styles.WithSign(attack)))
+	}
+	return notes
+}
+
+func abilityLabel(v int) string {
+	for _, m := range styles.AbilityStrings {
+		if m.Value == v {
+			return m.Label
+		}
+	}
+	return ""
+}
@@ -1,6 +1,7 @@
 package screen
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -32,6 +33,8 @@ type InventoryScreen struct {
 	electrum *component.SimpleComponent[int]
 	gold     *component.SimpleComponent[int]
 	platinum *component.SimpleComponent[int]
+	load     *list.List
+	attuned  *list.List
 	itemList *list.List
 
 	itemRows *Collection[models.ItemTO]
@@ -46,6 +49,17 @@ func NewInventoryScreen(k util.KeyMap, c *repository.CharacterAggregate) *Invent
 		electrum:  component.NewSimpleIntComponent(k, "EP", &c.Wallet.Electrum, true, true),
 		gold:      component.NewSimpleIntComponent(k, "GP", &c.Wallet.Gold, true, true),
 		platinum:  component.NewSimpleIntComponent(k, "PP", &c.Wallet.Platinum, true, true),
+		load: list.NewListWithDefaults(k).WithRows([]list.Row{
+			list.NewStructRow(k, c, renderLoadRow, []editor.ValueEditor{
+				editor.NewEnumEditor(k, styles.BinarySymbols, "Count Coin Weight", &c.Character.CoinWeight),
+				editor.NewEnumEditor(k, styles.BinarySymbols, "Variant Encumbrance", &c.Character.VariantEncumbrance),
+			}).WithReader(renderEncumbrance),
+		}),
+		attuned: list.NewListWithDefaults(k).WithRows([]list.Row{
+			list.NewStructRow(k, c, renderAttunementRow, []editor.ValueEditor{
+				editor.NewIntEditor(k, "Attunement Limit", &c.Character.AttunementLimit),
+			}).WithReader(renderAttunedItems),
+		}),
 		itemList: list.NewList(k, list.LeftAlignedListStyle).
 			WithFixedWidth(itemColWidth).
 			WithViewport(itemColHeight - 2).
@@ -60,7 +74,8 @@ func NewInventoryScreen(k util.KeyMap, c *repository.CharacterAggregate) *Invent
 			return list.NewStructRow(s.keymap, item, renderItemInfoRow,
 				createItemEditors(s.keymap, item)).
 				WithReader(renderFullItemInfo).
-				WithSearchText(itemSearchText)
+				WithSearchText(itemSearchText).
+				WithCycleAction(toggleItemEquipped)
 		},
 	)
 	return s
@@ -120,7 +135,17 @@ func (s *InventoryScreen) wireFocusGraph() {
 			command.DownDirection:  To(s.itemList),
 		},
 		s.platinum: {
-			command.LeftDirection: To(s.gold),
+			command.LeftDirection:  To(s.gold),
+			command.RightDirection: To(s.load),
+			command.DownDirection:  To(s.itemList),
+		},
+		s.load: {
+			command.LeftDirection:  To(s.platinum),
+			command.RightDirection: To(s.attuned),
+			command.DownDirection:  To(s.itemList),
+		},
+		s.attuned: {
+			command.LeftDirection: To(s.load),
 			command.DownDirection: To(s.itemList),
 		},
 		s.itemList: {
@@ -137,27 +162,47 @@ func createItemEditors(k util.KeyMap, item *models.ItemTO) []editor.ValueEditor
 		editor.NewEnumEditor(k, styles.BinarySymbols, "Equipped", &item.Equipped).
 			WithDisabledWhen(func() bool { return item.IsEquippable == 0 }),
 		editor.NewEnumEditor(k, styles.AttunementSymbols, "Attunement Slots", &item.AttunementSlots),
+		editor.NewEnumEditor(k, styles.BinarySymbols, "Attuned", &item.Attuned).
+			WithDisabledWhen(func() bool { return item.AttunementSlots == 0 }),
 		editor.NewIntEditor(k, "Quantity", &item.Quantity),
+		editor.NewFloatEditor(k, "Weight (lb)", &item.Weight),
+		editor.NewIntEditor(k, "Armor Base AC (0 = not armor)", &item.ArmorBaseAC),
+		editor.NewOptionalIntEditor(k, "Armor Dex Cap (empty = none)", &item.ArmorDexCap),
+		editor.NewIntEditor(k, "Shield Bonus", &item.ShieldBonus),
+		editor.NewIntEditor(k, "Magic AC Bonus", &item.MagicACBonus),
 		editor.NewTextEditor(k, "Description", &item.Description),
 	}
 }
 
+// toggleItemEquipped equips or unequips the item, which updates the derived AC.
+func toggleItemEquipped(item *models.ItemTO) tea.Cmd {
+	if item.IsEquippable == 0 {
+		return nil
+	}
+	item.Equipped = 1 - item.Equipped
+	return command.WriteBackRequest
+}
+
 func (s *InventoryScreen) RenderInventoryScreenTopBar() string {
-	separator := styles.GrayTextStyle.Width(6).Render(styles.MakeVerticalSeparator(1))
+	separator := styles.GrayTextStyle.Width(3).Render(styles.MakeVerticalSeparator(1))
 	return styles.DefaultBorderStyle.
 		Width(styles.ScreenWidth).
 		AlignHorizontal(lipgloss.Left).
 		Render(lipgloss.JoinHorizontal(
 			lipgloss.Center,
-			styles.ForceWidth(s.copper.View().Content, 15),
+			styles.ForceWidth(s.copper.View().Content, 9),
+			separator,
+			styles.ForceWidth(s.silver.View().Content, 9),
+			separator,
+			styles.ForceWidth(s.electrum.View().Content, 9),
 			separator,
-			styles.ForceWidth(s.silver.View().Content, 15),
+			styles.ForceWidth(s.gold.View().Content, 9),
 			separator,
-			styles.ForceWidth(s.electrum.View().Content, 15),
+			styles.ForceWidth(s.platinum.View().Content, 9),
 			separator,
-			styles.ForceWidth(s.gold.View().Content, 15),
+			styles.ForceWidth(s.load.View().Content, 21),
 			separator,
-			styles.ForceWidth(s.platinum.View().Content, 15),
+			s.attuned.View().Content,
 		))
 }
 
@@ -167,6 +212,9 @@ func itemSearchText(i *models.ItemTO) string {
 
 func renderItemInfoRow(i *models.ItemTO) string {
 	values := []string{drawItemPrefix(i), i.Name, styles.PrettyAttunementSlots(i.AttunementSlots)}
+	if i.Attuned == 1 {
+		values = append(values, "attuned")
+	}
 	values = util.Filter(values, func(s string) bool { return s != "" })
 	return strings.Join(values, " ∙ ")
 }
@@ -177,24 +225,104 @@ func renderFullItemInfo(i *models.ItemTO) string {
 	if i.IsEquippable == 1 {
 		equippedValue = drawItemPrefix(i)
 	}
-	content := strings.Join(
-		[]string{
-			i.Name,
-			separator,
-			"Equipped: " + equippedValue,
-			separator,
-			"Attunement slots required: " + styles.PrettyAttunementSlots(i.AttunementSlots),
-			separator,
-			"Quantity: " + strconv.Itoa(i.Quantity),
-			separator,
-			i.Description,
-		},
-		"\n")
+	lines := []string{
+		i.Name,
+		separator,
+		"Equipped: " + equippedValue,
+		separator,
+		"Attunement slots required: " + styles.PrettyAttunementSlots(i.AttunementSlots),
+		"Attuned: " + styles.PrettyBool(i.Attuned == 1),
+		separator,
+		"Quantity: " + strconv.Itoa(i.Quantity),
+		"Weight: " + styles.PrettyWeight(i.Weight) + " lb",
+		separator,
+	}
+	if armor := renderArmorInfo(i); armor != "" {
+		lines = append(lines, armor, separator)
+	}
+	content := strings.Join(append(lines, i.Description), "\n")
 	return styles.DefaultTextStyle.
 		AlignHorizontal(lipgloss.Left).
 		Render(content)
 }
 
+func renderLoadRow(a *repository.CharacterAggregate) string {
+	return styles.PrettyLoadBar(a.CarriedWeight(), a.CarryingCapacity(), 6) + " " +
+		styles.PrettyWeight(a.CarriedWeight()) + "/" + styles.PrettyWeight(a.CarryingCapacity()) + " lb"
+}
+
+// renderEncumbrance explains the carried weight and its effect on speed.
+func renderEncumbrance(a *repository.CharacterAggregate) string {
+	separator := styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)
+	state := styles.EncumbranceStrings[a.Encumbrance()].Label
+	lines := []string{
+		"Encumbrance: " + state,
+		separator,
+		"Carried:  " + styles.PrettyWeight(a.CarriedWeight()) + " lb",
+		"Coins:    " + styles.PrettyWeight(a.CoinWeight()) + " lb",
+		"Capacity: " + styles.PrettyWeight(a.CarryingCapacity()) + " lb",
+	}
+	if a.Character.VariantEncumbrance == 1 {
+		encumbered, heavily := a.EncumbranceThresholds()
+		lines = append(lines,
+			"Encumbered above "+styles.PrettyWeight(encumbered)+" lb (-10 ft)",
+			"Heavily encumbered above "+styles.PrettyWeight(heavily)+" lb (-20 ft)")
+	}
+	lines = append(lines, separator)
+	if penalty := a.ExhaustionSpeedPenalty(); penalty > 0 {
+		lines = append(lines, fmt.Sprintf("Exhaustion: -%d ft", penalty))
+	}
+	lines = append(lines, fmt.Sprintf("Speed: %d ft (base %d ft)", a.Speed(), a.Character.Speed))
+	return styles.DefaultTextStyle.
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderAttunementRow warns with a ! when the attuned items exceed the limit.
+func renderAttunementRow(a *repository.CharacterAggregate) string {
+	s := fmt.Sprintf("%d/%d attuned", a.AttunedSlots(), a.Character.AttunementLimit)
+	if a.AttunedSlots() > a.Character.AttunementLimit {
+		s += "!"
+	}
+	return s
+}
+
+func renderAttunedItems(a *repository.CharacterAggregate) string {
+	lines := []string{
+		fmt.Sprintf("Attunement: %d of %d slots used", a.AttunedSlots(), a.Character.AttunementLimit),
+		styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1),
+	}
+	for _, it := range a.Items {
+		if it.Attuned == 1 {
+			lines = append(lines, it.Name+" "+styles.PrettyAttunementSlots(it.AttunementSlots))
+		}
+	}
+	if a.AttunedSlots() > a.Character.AttunementLimit {
+		lines = append(lines, "", styles.GrayTextStyle.Render("More items are attuned than the limit allows."))
+	}
+	return styles.DefaultTextStyle.
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
+
+func renderArmorInfo(i *models.ItemTO) string {
+	values := []string{}
+	if i.ArmorBaseAC > 0 {
+		armor := "Armor: AC " + strconv.Itoa(i.ArmorBaseAC) + " + Dex"
+		if i.ArmorDexCap.Valid {
+			armor += " (max " + strconv.Itoa(i.ArmorDexCap.Int) + ")"
+		}
+		values = append(values, armor)
+	}
+	if i.ShieldBonus != 0 {
+		values = append(values, "Shield: "+styles.WithSign(i.ShieldBonus)+" AC")
+	}
+	if i.MagicACBonus != 0 {
+		values = append(values, "Magic bonus: "+styles.WithSign(i.MagicACBonus)+" AC")
+	}
+	return strings.Join(values, "\n")
+}
+
 func drawItemPrefix(i *models.ItemTO) string {
 	if i.IsEquippable == 0 {
 		return strconv.Itoa(i.Quantity)
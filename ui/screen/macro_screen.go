@@ -0,0 +1,122 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/google/uuid"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/editor"
+	"hostettler.dev/dnc/ui/list"
+	"hostettler.dev/dnc/ui/quickaction"
+	"hostettler.dev/dnc/ui/styles"
+	"hostettler.dev/dnc/util"
+)
+
+var (
+	macroScreenHeight = 16
+	macroListWidth    = styles.SmallScreenWidth - 6
+)
+
+// MacroScreen lists the named dice expressions of a character, rolled with
+// e.g. "roll smite".
+type MacroScreen struct {
+	keymap    util.KeyMap
+	character *repository.CharacterAggregate
+	FocusManager
+
+	macroList *list.List
+
+	macroRows *Collection[models.MacroTO]
+}
+
+func NewMacroScreen(k util.KeyMap, c *repository.CharacterAggregate) *MacroScreen {
+	s := &MacroScreen{
+		keymap:    k,
+		character: c,
+		macroList: list.NewList(k, list.LeftAlignedListStyle).
+			WithTitle("Macros").
+			WithFixedWidth(macroListWidth).
+			WithViewport(macroScreenHeight - 6),
+	}
+	s.macroRows = NewCollection(k, s.macroList,
+		func() []*models.MacroTO { return util.Pointers(s.character.Macros) },
+		func(m *models.MacroTO) uuid.UUID { return m.ID },
+		s.character.AddEmptyMacro,
+		s.character.DeleteMacro,
+		func(m *models.MacroTO) *list.StructRow[models.MacroTO] {
+			return list.NewStructRow(s.keymap, m, renderMacroRow, createMacroEditors(s.keymap, s.character, m)).
+				WithRollAction(rollMacro).
+				WithReader(func(m *models.MacroTO) string { return renderFullMacro(s.character, m) })
+		},
+	).WithOnChange(s.populateMacros)
+	return s
+}
+
+func (s *MacroScreen) Init() tea.Cmd {
+	s.populateMacros()
+	s.Wire(FocusGraph{s.macroList: {}}, s.macroList)
+	return nil
+}
+
+func (s *MacroScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if key.Matches(msg, s.keymap.Escape) && !util.IsLetterKey(msg) {
+			return s, command.SwitchToPrevScreenCmd
+		}
+		_, cmd = s.macroList.Update(msg)
+	}
+	return s, cmd
+}
+
+func (s *MacroScreen) View() tea.View {
+	hint := styles.GrayTextStyle.Render("Use a macro with roll <name>, ev <name> or dist <name>.")
+	separator := styles.MakeHorizontalSeparator(macroListWidth, 1)
+	return tea.NewView(styles.DefaultBorderStyle.
+		Width(styles.SmallScreenWidth).
+		Height(macroScreenHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, s.macroList.View().Content, separator, hint)))
+}
+
+func (s *MacroScreen) populateMacros() {
+	s.macroList.WithSections([]list.Section{s.macroRows.Section()})
+}
+
+// createMacroEditors keeps the name unless quickaction.CheckMacroName
+// accepts the new one.
+func createMacroEditors(k util.KeyMap, c *repository.CharacterAggregate, m *models.MacroTO) []editor.ValueEditor {
+	return []editor.ValueEditor{
+		editor.NewCheckedStringEditor(k, "Name", &m.Name, func(name string) error {
+			return quickaction.CheckMacroName(c, m.ID, name)
+		}),
+		editor.NewStringEditor(k, "Expression", &m.Expression),
+	}
+}
+
+func rollMacro(m *models.MacroTO) tea.Cmd {
+	return command.RunQuickActionCmd("roll " + m.Name)
+}
+
+func renderMacroRow(m *models.MacroTO) string {
+	return fmt.Sprintf("%-12s %s", m.Name, m.Expression)
+}
+
+func renderFullMacro(agg *repository.CharacterAggregate, m *models.MacroTO) string {
+	lines := []string{
+		m.Name,
+		styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1),
+		"Expression: " + resolvedExpression(agg, m.Expression),
+	}
+	return styles.DefaultTextStyle.
+		Width(styles.SmallScreenWidth - 4).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
@@ -207,18 +207,12 @@ func (s *ProfileScreen) CreateCharacterInfoRows() {
 	rows := []list.Row{
 		list.NewLabeledStringRow(s.keymap, "Name:", &s.agg.Character.Name,
 			editor.NewStringEditor(s.keymap, "Name", &s.agg.Character.Name)).WithConfig(rowCfg),
-		list.NewLabeledStringRow(s.keymap, "Levels:", &s.agg.Character.ClassLevels,
-			editor.NewStringEditor(s.keymap, "Levels", &s.agg.Character.ClassLevels)).WithConfig(rowCfg),
+		NewClassLevelsRow(s.keymap, s.agg, profileLongColWidth),
 		list.NewLabeledStringRow(s.keymap, "Race:", &s.agg.Character.Race,
 			editor.NewStringEditor(s.keymap, "Race", &s.agg.Character.Race)).WithConfig(rowCfg),
 		list.NewLabeledStringRow(s.keymap, "Alignment:", &s.agg.Character.Alignment,
 			editor.NewStringEditor(s.keymap, "Alignment", &s.agg.Character.Alignment)).WithConfig(rowCfg),
-		list.NewLabeledIntRow(s.keymap, "Proficiency Bonus:", &s.agg.Character.ProficiencyBonus,
-			editor.NewIntEditor(s.keymap, "Proficiency Bonus", &s.agg.Character.ProficiencyBonus)).
-			WithConfig(list.LabeledIntRowConfig{
-				ValuePrinter: styles.WithSign,
-				JustifyValue: false, LabelWidth: profileLongColWidth, ValueWidth: 0,
-			}),
+		NewProficiencyBonusRow(s.keymap, s.agg, profileLongColWidth),
 	}
 	s.characterInfo.WithRows(rows)
 }
@@ -0,0 +1,142 @@
+package screen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/list"
+	"hostettler.dev/dnc/ui/styles"
+	"hostettler.dev/dnc/util"
+)
+
+var (
+	rollLogHeight = 30
+	rollLogWidth  = styles.ScreenWidth - 10
+)
+
+// RollLogScreen shows the dice rolled with the roll action grouped by day,
+// most recent first.
+type RollLogScreen struct {
+	keymap    util.KeyMap
+	character *repository.CharacterAggregate
+	FocusManager
+
+	rollList *list.List
+}
+
+func NewRollLogScreen(k util.KeyMap, c *repository.CharacterAggregate) *RollLogScreen {
+	return &RollLogScreen{
+		keymap:    k,
+		character: c,
+		rollList: list.NewList(k, list.LeftAlignedListStyle).
+			WithTitle("Rolls").
+			WithFixedWidth(rollLogWidth).
+			WithViewport(rollLogHeight - 4).
+			WithSectionStyle(list.SectionStyle{
+				HeaderSeparator: "─",
+				SectionGap:      " ",
+				SeparatorWidth:  rollLogWidth - 6,
+			}),
+	}
+}
+
+func (s *RollLogScreen) Init() tea.Cmd {
+	s.populateRolls()
+	s.Wire(FocusGraph{s.rollList: {}}, s.rollList)
+	return nil
+}
+
+// Focus rebuilds the log, rolls are made while the screen is hidden.
+func (s *RollLogScreen) Focus() {
+	s.populateRolls()
+	s.FocusManager.Focus()
+}
+
+func (s *RollLogScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if key.Matches(msg, s.keymap.Escape) && !util.IsLetterKey(msg) {
+			return s, command.SwitchToPrevScreenCmd
+		}
+		_, cmd = s.rollList.Update(msg)
+	}
+	return s, cmd
+}
+
+func (s *RollLogScreen) View() tea.View {
+	content := s.rollList.View().Content
+	if len(s.character.Rolls) == 0 {
+		content = styles.GrayTextStyle.Render("No dice rolled yet.")
+	}
+	return tea.NewView(styles.DefaultBorderStyle.
+		Width(styles.ScreenWidth).
+		Height(rollLogHeight).
+		Render(content))
+}
+
+func (s *RollLogScreen) populateRolls() {
+	var days [][]*models.RollTO
+	for i := len(s.character.Rolls) - 1; i >= 0; i-- {
+		r := &s.character.Rolls[i]
+		if n := len(days); n > 0 && sameDay(days[n-1][0], r) {
+			days[n-1] = append(days[n-1], r)
+		} else {
+			days = append(days, []*models.RollTO{r})
+		}
+	}
+	sections := make([]list.Section, 0, len(days))
+	for _, rolls := range days {
+		rows := make([]list.Row, 0, len(rolls))
+		for _, r := range rolls {
+			rows = append(rows, list.NewStructRow(s.keymap, r, renderRollRow, nil).
+				WithReader(renderFullRoll))
+		}
+		sections = append(sections, list.Section{
+			Header: list.NewStructRow(s.keymap, &rolls, renderRollDayRow, nil),
+			Items:  rows,
+		})
+	}
+	s.rollList.WithSections(sections)
+}
+
+func sameDay(a, b *models.RollTO) bool {
+	return a.CreatedAt.Local().Format("2006-01-02") == b.CreatedAt.Local().Format("2006-01-02")
+}
+
+func renderRollDayRow(rolls *[]*models.RollTO) string {
+	count := fmt.Sprintf("%d rolls", len(*rolls))
+	if len(*rolls) == 1 {
+		count = "1 roll"
+	}
+	return (*rolls)[0].CreatedAt.Local().Format("Mon 02 Jan 2006") + " ∙ " + count
+}
+
+func renderRollRow(r *models.RollTO) string {
+	return fmt.Sprintf("%s  %-20s %4d  %s", r.CreatedAt.Local().Format("15:04:05"), r.Expression, r.Total, r.Detail)
+}
+
+func renderFullRoll(r *models.RollTO) string {
+	separator := styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)
+	lines := []string{
+		r.Expression,
+		separator,
+		"Time: " + r.CreatedAt.Local().Format("Mon 02 Jan 2006 15:04:05"),
+		"Total: " + strconv.Itoa(r.Total),
+	}
+	if r.Detail != "" {
+		lines = append(lines, separator)
+		lines = append(lines, strings.Split(r.Detail, ", ")...)
+	}
+	return styles.DefaultTextStyle.
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
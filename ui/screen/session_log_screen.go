@@ -0,0 +1,134 @@
+package screen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"hostettler.dev/dnc/command"
+	"hostettler.dev/dnc/models"
+	"hostettler.dev/dnc/repository"
+	"hostettler.dev/dnc/ui/list"
+	"hostettler.dev/dnc/ui/styles"
+	"hostettler.dev/dnc/util"
+)
+
+var (
+	sessionLogHeight = 30
+	sessionLogWidth  = styles.ScreenWidth - 10
+)
+
+// SessionLogScreen shows the gameplay actions recorded in the session log,
+// most recent session first.
+type SessionLogScreen struct {
+	keymap    util.KeyMap
+	character *repository.CharacterAggregate
+	FocusManager
+
+	eventList *list.List
+}
+
+func NewSessionLogScreen(k util.KeyMap, c *repository.CharacterAggregate) *SessionLogScreen {
+	return &SessionLogScreen{
+		keymap:    k,
+		character: c,
+		eventList: list.NewList(k, list.LeftAlignedListStyle).
+			WithTitle("Session Log").
+			WithFixedWidth(sessionLogWidth).
+			WithViewport(sessionLogHeight - 4).
+			WithSectionStyle(list.SectionStyle{
+				HeaderSeparator: "─",
+				SectionGap:      " ",
+				SeparatorWidth:  sessionLogWidth - 6,
+			}),
+	}
+}
+
+func (s *SessionLogScreen) Init() tea.Cmd {
+	s.populateEvents()
+	s.Wire(FocusGraph{s.eventList: {}}, s.eventList)
+	return nil
+}
+
+// Focus rebuilds the timeline, events are logged while the screen is hidden.
+func (s *SessionLogScreen) Focus() {
+	s.populateEvents()
+	s.FocusManager.Focus()
+}
+
+func (s *SessionLogScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if key.Matches(msg, s.keymap.Escape) && !util.IsLetterKey(msg) {
+			return s, command.SwitchToPrevScreenCmd
+		}
+		_, cmd = s.eventList.Update(msg)
+	}
+	return s, cmd
+}
+
+func (s *SessionLogScreen) View() tea.View {
+	content := s.eventList.View().Content
+	if len(s.character.Events) == 0 {
+		content = styles.GrayTextStyle.Render("No gameplay actions logged yet.")
+	}
+	return tea.NewView(styles.DefaultBorderStyle.
+		Width(styles.ScreenWidth).
+		Height(sessionLogHeight).
+		Render(content))
+}
+
+func (s *SessionLogScreen) populateEvents() {
+	sessions := s.character.Sessions()
+	slices.Reverse(sessions)
+	sections := make([]list.Section, 0, len(sessions))
+	for _, events := range sessions {
+		rows := make([]list.Row, 0, len(events))
+		for _, e := range events {
+			rows = append(rows, list.NewStructRow(s.keymap, e, renderSessionEventRow, nil).
+				WithReader(renderFullSessionEvent))
+		}
+		sections = append(sections, list.Section{
+			Header: list.NewStructRow(s.keymap, &events, renderSessionHeaderRow, nil),
+			Items:  rows,
+		})
+	}
+	s.eventList.WithSections(sections)
+}
+
+func renderSessionHeaderRow(events *[]*models.SessionEventTO) string {
+	first, last := (*events)[0], (*events)[len(*events)-1]
+	return fmt.Sprintf("Session ∙ %s – %s ∙ %d actions",
+		first.CreatedAt.Local().Format("Mon 02 Jan 2006 15:04"),
+		last.CreatedAt.Local().Format("15:04"),
+		len(*events))
+}
+
+func renderSessionEventRow(e *models.SessionEventTO) string {
+	action := strings.TrimSpace(e.Action + " " + e.Args)
+	change := e.Before + " → " + e.After
+	return fmt.Sprintf("%s  %-14s %s", e.CreatedAt.Local().Format("15:04:05"), action, change)
+}
+
+func renderFullSessionEvent(e *models.SessionEventTO) string {
+	separator := styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)
+	content := strings.Join(
+		[]string{
+			strings.TrimSpace(e.Action + " " + e.Args),
+			separator,
+			"Actor: " + e.Actor,
+			"Time: " + e.CreatedAt.Local().Format("Mon 02 Jan 2006 15:04:05"),
+			separator,
+			"Before: " + e.Before,
+			"After:  " + e.After,
+		},
+		"\n")
+	return styles.DefaultTextStyle.
+		AlignHorizontal(lipgloss.Left).
+		Render(content)
+}
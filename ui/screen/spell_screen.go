@@ -29,9 +29,9 @@ type SpellScreen struct {
 	character *repository.CharacterAggregate
 	FocusManager
 
-	spellAbility  *component.SimpleComponent[string]
-	spellSaveDC   *component.SimpleComponent[int]
-	spellAtkBonus *component.SimpleComponent[int]
+	spellAbility  *component.SimpleComponent[int]
+	spellSaveDC   *list.List
+	spellAtkBonus *list.List
 	spellList     *list.List
 
 	perLevelRows [10]*Collection[models.SpellTO]
@@ -39,11 +39,12 @@ type SpellScreen struct {
 
 func NewSpellScreen(k util.KeyMap, c *repository.CharacterAggregate) *SpellScreen {
 	s := &SpellScreen{
-		keymap:        k,
-		character:     c,
-		spellAbility:  component.NewSimpleStringComponent(k, "Spellcasting Ability", &c.Character.SpellcastingAbility, true, true),
-		spellSaveDC:   component.NewSimpleIntComponent(k, "Spell Save DC", &c.Character.SpellSaveDC, true, true),
-		spellAtkBonus: component.NewSimpleIntComponent(k, "Spell Attack Bonus", &c.Character.SpellAttackBonus, true, true),
+		keymap:    k,
+		character: c,
+		spellAbility: component.NewSimpleEnumComponent(k, "Spellcasting Ability", &c.Character.SpellcastingAbility,
+			styles.AbilityStrings, true, true),
+		spellSaveDC:   list.NewListWithDefaults(k).WithRows([]list.Row{newSpellSaveDCRow(k, c)}),
+		spellAtkBonus: list.NewListWithDefaults(k).WithRows([]list.Row{newSpellAttackBonusRow(k, c)}),
 		spellList: list.NewList(k, list.ListStyles{
 			Row:      styles.ItemStyleDefault.Align(lipgloss.Left),
 			Selected: styles.ItemStyleSelected.Align(lipgloss.Left),
@@ -67,7 +68,7 @@ func NewSpellScreen(k util.KeyMap, c *repository.CharacterAggregate) *SpellScree
 			func(sp *models.SpellTO) *list.StructRow[models.SpellTO] {
 				return list.NewStructRow(s.keymap, sp, renderSpellInfoRow,
 					s.createSpellEditors(sp)).
-					WithReader(renderFullSpellInfo).
+					WithReader(func(sp *models.SpellTO) string { return renderFullSpellInfo(s.character, sp) }).
 					WithSearchText(spellSearchText).
 					WithCycleAction(toggleSpellPrepared)
 			},
@@ -175,33 +176,77 @@ func (s *SpellScreen) RenderSpellScreenTopBar() string {
 			styles.ForceWidth(s.spellAtkBonus.View().Content, spellTopBarElemWidth)))
 }
 
+// SpellListHeader shows the slots of one spell level. The maximum is derived
+// from the class levels unless overridden, pact slots are shown on the
+// header of their spell level.
 type SpellListHeader struct {
-	level int
-	slots *int
-	used  *int
+	level     int
+	character *repository.CharacterAggregate
+}
+
+func (h *SpellListHeader) slots() int {
+	return h.character.SpellSlots()[h.level]
+}
+
+func (h *SpellListHeader) used() *int {
+	return &h.character.Character.SpellSlotsUsed[h.level]
+}
+
+func (h *SpellListHeader) pactSlots() int {
+	slots, slotLevel := h.character.PactSlots()
+	if slotLevel != h.level {
+		return 0
+	}
+	return slots
 }
 
 func (s *SpellScreen) newSpellHeaderRow(l int) *list.StructRow[SpellListHeader] {
+	c := s.character.Character
+	editors := []editor.ValueEditor{
+		editor.NewIntEditor(s.keymap, "Used Spell Slots", &c.SpellSlotsUsed[l]),
+		editor.NewOptionalIntEditor(s.keymap, "Max Spell Slots (empty = auto)", &c.SpellSlotOverrides[l]),
+	}
+	if _, pactLevel := s.character.PactSlots(); pactLevel == l {
+		editors = append(editors,
+			editor.NewIntEditor(s.keymap, "Used Pact Slots", &c.PactSlotsUsed),
+			editor.NewOptionalIntEditor(s.keymap, "Pact Slots (empty = auto)", &c.PactSlots),
+			editor.NewOptionalIntEditor(s.keymap, "Pact Slot Level (empty = auto)", &c.PactSlotLevel),
+		)
+	}
 	return list.NewStructRow(s.keymap,
-		&SpellListHeader{l, &s.character.Character.SpellSlots[l], &s.character.Character.SpellSlotsUsed[l]},
+		&SpellListHeader{l, s.character},
 		renderSpellHeaderRow,
-		[]editor.ValueEditor{
-			editor.NewIntEditor(s.keymap, "Used Spell Slots", &s.character.Character.SpellSlotsUsed[l]),
-			editor.NewIntEditor(s.keymap, "Max Spell Slots", &s.character.Character.SpellSlots[l]),
-		}).WithCycleAction(cycleSpellSlots)
+		editors).WithCycleAction(cycleSpellSlots)
 }
 
+// cycleSpellSlots marks the next slot as used, pact slots once the regular
+// slots of the level are exhausted.
 func cycleSpellSlots(h *SpellListHeader) tea.Cmd {
-	if *h.slots <= 0 {
+	slots, used := h.slots(), h.used()
+	pact := h.pactSlots()
+	pactUsed := &h.character.Character.PactSlotsUsed
+	switch {
+	case slots <= 0 && pact <= 0:
 		return nil
+	case *used < slots:
+		*used++
+	case *pactUsed < pact:
+		*pactUsed++
+	default:
+		*used = 0
+		*pactUsed = 0
 	}
-	*h.used = (*h.used + 1) % (*h.slots + 1)
 	return command.WriteBackRequest
 }
 
 func renderSpellHeaderRow(h *SpellListHeader) string {
-	return fmt.Sprintf("Level %d ∙ %s", h.level,
-		styles.PrettySpellSlots(*h.used, *h.slots))
+	derived := !h.character.Character.SpellSlotOverrides[h.level].Valid
+	header := fmt.Sprintf("Level %d ∙ %s%s", h.level,
+		styles.PrettySpellSlots(*h.used(), h.slots()), styles.DerivedMarker(derived && h.slots() > 0))
+	if pact := h.pactSlots(); pact > 0 {
+		header += "∙ Pact " + styles.PrettySpellSlots(h.character.Character.PactSlotsUsed, pact)
+	}
+	return header
 }
 
 func spellSearchText(s *models.SpellTO) string {
@@ -225,7 +270,17 @@ func renderSpellInfoRow(s *models.SpellTO) string {
 	return styles.PrettyBoolCircle(util.I2b(s.Prepared)) + " " + strings.Join(values, " ∙ ")
 }
 
-func renderFullSpellInfo(s *models.SpellTO) string {
+// resolvedExpression appends the current value of a dice expression using
+// variables, e.g. "1d8 + @wis (1d8 + 3)".
+func resolvedExpression(agg *repository.CharacterAggregate, expr string) string {
+	expanded, err := agg.ExpandVariables(expr)
+	if err != nil || expanded == expr {
+		return expr
+	}
+	return expr + " (" + expanded + ")"
+}
+
+func renderFullSpellInfo(agg *repository.CharacterAggregate, s *models.SpellTO) string {
 	innerWidth := styles.SmallScreenWidth - 4
 	colWidth := innerWidth / 2
 	separator := styles.MakeHorizontalSeparator(innerWidth, 0)
@@ -247,7 +302,7 @@ func renderFullSpellInfo(s *models.SpellTO) string {
 		{"Range", s.Range},
 		{"Duration", s.Duration},
 		{"Components", s.Components},
-		{"Damage", s.Damage},
+		{"Damage", resolvedExpression(agg, s.Damage)},
 	}
 	pairs = util.Filter(pairs, func(p struct{ label, value string }) bool { return p.value != "" })
 
@@ -3,6 +3,7 @@ package screen
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
@@ -13,6 +14,7 @@ import (
 	"hostettler.dev/dnc/ui/component"
 	"hostettler.dev/dnc/ui/editor"
 	"hostettler.dev/dnc/ui/list"
+	"hostettler.dev/dnc/ui/quickaction"
 	"hostettler.dev/dnc/ui/styles"
 	"hostettler.dev/dnc/util"
 )
@@ -20,7 +22,7 @@ import (
 var (
 	statTopBarHeight = 6
 
-	statColHeight    = 27
+	statColHeight    = 28
 	statLeftColWidth = 32
 	statMidColWidth  = 30
 
@@ -35,9 +37,14 @@ var (
 
 	statAbilityCellWidth = 14
 
-	statActionHeight = 5
+	statActionHeight   = 5
+	statAttackHeight   = 3
+	statResourceHeight = 3
 
 	statStatusLabelWidth = 15
+
+	statConditionLabelWidth = 10
+	statConditionValueWidth = 12
 )
 
 type StatScreen struct {
@@ -47,18 +54,21 @@ type StatScreen struct {
 
 	characterInfo *list.List
 	abilities     []*component.SimpleComponent[int]
-	concentration *component.SimpleComponent[int]
+	concentration *list.List
 	inspiration   *component.SimpleComponent[int]
-	exhaustion    *component.SimpleComponent[int]
-	condition     *component.SimpleComponent[string]
+	exhaustion    *list.List
+	conditions    *list.List
 	skills        *list.List
+	passives      *list.List
 	savingThrows  *list.List
 	combatInfo    *list.List
 	attacks       *list.List
+	resources     *list.List
 	actions       *component.SimpleTextComponent
 	bonusActions  *component.SimpleTextComponent
 
-	attackRows *Collection[models.AttackTO]
+	attackRows   *Collection[models.AttackTO]
+	resourceRows *Collection[models.ResourceTO]
 }
 
 func NewStatScreen(km util.KeyMap, c *repository.CharacterAggregate) *StatScreen {
@@ -67,19 +77,23 @@ func NewStatScreen(km util.KeyMap, c *repository.CharacterAggregate) *StatScreen
 		agg:           c,
 		actions:       component.NewSimpleTextComponent(km, "Actions", &c.Character.Actions, statActionHeight, statRightContentWidth),
 		bonusActions:  component.NewSimpleTextComponent(km, "Bonus Actions", &c.Character.BonusActions, statActionHeight, statRightContentWidth),
-		concentration: component.NewSimpleEnumComponent(km, "Concentration", &c.Character.Concentration, styles.BinarySymbols, true, true).WithLabelWidth(statStatusLabelWidth),
+		concentration: list.NewListWithDefaults(km).WithRows([]list.Row{newConcentrationRow(km, c)}),
 		inspiration:   component.NewSimpleEnumComponent(km, "Inspiration", &c.Character.Inspiration, styles.BinarySymbols, true, true).WithLabelWidth(statStatusLabelWidth),
-		exhaustion:    component.NewSimpleEnumComponent(km, "Exhaustion", &c.Character.Exhaustion, styles.ExhaustionSymbols, true, true),
-		condition:     component.NewSimpleStringComponent(km, "Condition", &c.Character.Condition, true, true),
+		exhaustion:    list.NewListWithDefaults(km).WithRows([]list.Row{newExhaustionRow(km, c)}),
+		conditions: list.NewListWithDefaults(km).WithFixedWidth(statConditionLabelWidth + 1 + statConditionValueWidth).
+			WithRows([]list.Row{NewConditionsRow(km, c, statConditionLabelWidth, statConditionValueWidth)}),
 		characterInfo: list.NewListWithDefaults(km),
 		skills: list.NewListWithDefaults(km).
 			WithTitle("Skills"),
+		passives: list.NewListWithDefaults(km),
 		savingThrows: list.NewListWithDefaults(km).
 			WithTitle("Saving Throws"),
 		combatInfo: list.NewListWithDefaults(km).
 			WithTitle("Combat"),
 		attacks: list.NewListWithDefaults(km).
-			WithTitle("Attacks").WithViewport(4),
+			WithTitle("Attacks").WithViewport(statAttackHeight),
+		resources: list.NewListWithDefaults(km).
+			WithTitle("Resources").WithViewport(statResourceHeight),
 	}
 	scorePrinter := func(score int) string {
 		return fmt.Sprintf("%2s [%+d]", strconv.Itoa(score), models.ToModifier(score, 0, 0))
@@ -106,7 +120,28 @@ func NewStatScreen(km util.KeyMap, c *repository.CharacterAggregate) *StatScreen
 				editor.NewIntEditor(s.keymap, "Bonus", &a.Bonus),
 				editor.NewStringEditor(s.keymap, "Damage", &a.Damage),
 				editor.NewStringEditor(s.keymap, "Damage Type", &a.DamageType),
-			})
+			}).
+				WithRollAction(rollAttack).
+				WithShowAction(func(a *models.AttackTO) tea.Cmd {
+					return quickaction.AttackAnalysisCmd(s.agg, a, quickaction.DefaultMinAC, quickaction.DefaultMaxAC, models.NoEffect)
+				})
+		},
+	)
+	s.resourceRows = NewCollection(km, s.resources,
+		func() []*models.ResourceTO { return util.Pointers(s.agg.Resources) },
+		func(r *models.ResourceTO) uuid.UUID { return r.ID },
+		s.agg.AddEmptyResource,
+		s.agg.DeleteResource,
+		func(r *models.ResourceTO) *list.StructRow[models.ResourceTO] {
+			return list.NewStructRow(s.keymap, r, renderResourceRow(s.agg), []editor.ValueEditor{
+				editor.NewStringEditor(s.keymap, "Name", &r.Name),
+				editor.NewEnumEditor(s.keymap, styles.ResourceFormulaStrings, "Max Formula", &r.MaxFormula),
+				editor.NewIntEditor(s.keymap, "Maximum (bonus with a formula)", &r.Maximum),
+				editor.NewStringEditor(s.keymap, "Class (Level formula, empty = character level)", &r.MaxClass),
+				editor.NewEnumEditor(s.keymap, styles.AbilityStrings, "Ability (Ability Modifier formula)", &r.MaxAbility),
+				editor.NewIntEditor(s.keymap, "Used", &r.Used),
+				editor.NewEnumEditor(s.keymap, styles.RechargeStrings, "Recharge", &r.Recharge),
+			}).WithCycleAction(cycleResource(s.agg))
 		},
 	)
 	return s
@@ -121,18 +156,22 @@ func (s *StatScreen) Init() tea.Cmd {
 	cmds = append(cmds, s.concentration.Init())
 	cmds = append(cmds, s.inspiration.Init())
 	cmds = append(cmds, s.exhaustion.Init())
-	cmds = append(cmds, s.condition.Init())
+	cmds = append(cmds, s.conditions.Init())
 	cmds = append(cmds, s.skills.Init())
+	cmds = append(cmds, s.passives.Init())
 	cmds = append(cmds, s.savingThrows.Init())
 	cmds = append(cmds, s.combatInfo.Init())
 	cmds = append(cmds, s.attacks.Init())
+	cmds = append(cmds, s.resources.Init())
 	cmds = append(cmds, s.actions.Init())
 	cmds = append(cmds, s.bonusActions.Init())
 
 	s.CreateCharacterInfoRows()
 	s.CreateSkillRows()
+	s.CreatePassiveRows()
 	s.CreateCombatInfoRows()
 	s.attackRows.Repopulate()
+	s.resourceRows.Repopulate()
 	s.CreateSavingThrowRows()
 
 	s.wireFocusGraph()
@@ -189,15 +228,15 @@ func (s *StatScreen) wireFocusGraph() {
 		ab[5]: {
 			command.LeftDirection: To(ab[4]),
 			command.UpDirection:   To(ab[2]),
-			command.DownDirection: To(s.condition),
+			command.DownDirection: To(s.conditions),
 		},
 		s.concentration: {
 			command.UpDirection:    To(ab[3]),
-			command.RightDirection: To(s.condition),
+			command.RightDirection: To(s.conditions),
 			command.DownDirection:  To(s.inspiration),
 			command.LeftDirection:  To(s.characterInfo),
 		},
-		s.condition: {
+		s.conditions: {
 			command.UpDirection:   To(ab[5]),
 			command.LeftDirection: To(s.concentration),
 			command.DownDirection: To(s.exhaustion),
@@ -209,12 +248,13 @@ func (s *StatScreen) wireFocusGraph() {
 			command.LeftDirection:  To(s.characterInfo),
 		},
 		s.exhaustion: {
-			command.UpDirection:   To(s.condition),
+			command.UpDirection:   To(s.conditions),
 			command.LeftDirection: To(s.inspiration),
 			command.DownDirection: To(s.actions),
 		},
 		s.skills: {
 			command.UpDirection:   To(s.characterInfo),
+			command.DownDirection: To(s.passives),
 			command.LeftDirection: Emit(command.ReturnFocusToParentCmd),
 			command.RightDirection: ToCond(func() FocusableModel {
 				if s.skills.CursorPos() < s.skills.Size()/2 {
@@ -223,6 +263,11 @@ func (s *StatScreen) wireFocusGraph() {
 				return s.savingThrows
 			}),
 		},
+		s.passives: {
+			command.UpDirection:    ToWith(s.skills, func() { s.skills.SetCursor(s.skills.Size() - 1) }),
+			command.LeftDirection:  Emit(command.ReturnFocusToParentCmd),
+			command.RightDirection: To(s.savingThrows),
+		},
 		s.combatInfo: {
 			command.UpDirection:    To(s.characterInfo),
 			command.RightDirection: To(s.actions),
@@ -247,6 +292,11 @@ func (s *StatScreen) wireFocusGraph() {
 		s.attacks: {
 			command.UpDirection:   To(s.bonusActions),
 			command.LeftDirection: To(s.savingThrows),
+			command.DownDirection: To(s.resources),
+		},
+		s.resources: {
+			command.UpDirection:   To(s.attacks),
+			command.LeftDirection: To(s.savingThrows),
 		},
 	}, s.characterInfo)
 }
@@ -260,7 +310,7 @@ func (s *StatScreen) View() tea.View {
 	statCell := func(content string) string {
 		return lipgloss.NewStyle().Width(statColWidth + statTinyColWidth).Render(content)
 	}
-	concentrationLine := lipgloss.JoinHorizontal(lipgloss.Left, statCell(s.concentration.View().Content), s.condition.View().Content)
+	concentrationLine := lipgloss.JoinHorizontal(lipgloss.Left, statCell(s.concentration.View().Content), s.conditions.View().Content)
 	inspirationLine := lipgloss.JoinHorizontal(lipgloss.Left, statCell(s.inspiration.View().Content), s.exhaustion.View().Content)
 	abilities := lipgloss.JoinVertical(lipgloss.Left,
 		lipgloss.JoinHorizontal(lipgloss.Top, abilityCell(0), abilityCell(1), abilityCell(2)),
@@ -282,7 +332,10 @@ func (s *StatScreen) View() tea.View {
 	leftColumn := styles.DefaultBorderStyle.
 		Height(statColHeight).
 		Width(statLeftColWidth).
-		Render(s.skills.View().Content)
+		Render(lipgloss.JoinVertical(lipgloss.Center,
+			s.skills.View().Content,
+			styles.GrayTextStyle.Render("Passive Scores"),
+			s.passives.View().Content))
 
 	savingThrows := s.savingThrows.View().Content
 
@@ -299,12 +352,14 @@ func (s *StatScreen) View() tea.View {
 
 	attacks := s.attacks.View().Content
 
+	resources := s.resources.View().Content
+
 	rightBoxInnerSeparator := styles.MakeHorizontalSeparator(statRightContentWidth, 1)
 
 	rightColumn := styles.DefaultBorderStyle.
 		Width(statRightColWidth).
 		Height(statColHeight).
-		Render(lipgloss.JoinVertical(lipgloss.Center, actions, rightBoxInnerSeparator, attacks))
+		Render(lipgloss.JoinVertical(lipgloss.Center, actions, rightBoxInnerSeparator, attacks, rightBoxInnerSeparator, resources))
 
 	body := lipgloss.JoinHorizontal(lipgloss.Left, leftColumn, midColumn, rightColumn)
 
@@ -328,53 +383,38 @@ func (s *StatScreen) CreateCharacterInfoRows() {
 	rows := []list.Row{
 		list.NewLabeledStringRow(s.keymap, "Name:", &s.agg.Character.Name,
 			editor.NewStringEditor(s.keymap, "Name", &s.agg.Character.Name)).WithConfig(rowCfg),
-		list.NewLabeledStringRow(s.keymap, "Levels:", &s.agg.Character.ClassLevels,
-			editor.NewStringEditor(s.keymap, "Levels", &s.agg.Character.ClassLevels)).WithConfig(rowCfg),
+		NewClassLevelsRow(s.keymap, s.agg, statLongColWidth),
 		list.NewLabeledStringRow(s.keymap, "Race:", &s.agg.Character.Race,
 			editor.NewStringEditor(s.keymap, "Race", &s.agg.Character.Race)).WithConfig(rowCfg),
 		list.NewLabeledStringRow(s.keymap, "Alignment:", &s.agg.Character.Alignment,
 			editor.NewStringEditor(s.keymap, "Alignment", &s.agg.Character.Alignment)).WithConfig(rowCfg),
-		list.NewLabeledIntRow(s.keymap, "Proficiency Bonus:", &s.agg.Character.ProficiencyBonus,
-			editor.NewIntEditor(s.keymap, "Proficiency Bonus", &s.agg.Character.ProficiencyBonus)).
-			WithConfig(list.LabeledIntRowConfig{
-				ValuePrinter: styles.WithSign,
-				JustifyValue: false, LabelWidth: statLongColWidth, ValueWidth: 0,
-			}),
+		NewProficiencyBonusRow(s.keymap, s.agg, statLongColWidth),
 	}
 	s.characterInfo.WithRows(rows)
 }
 
 func (s *StatScreen) CreateCombatInfoRows() {
-	standardCfg := list.LabeledIntRowConfig{
-		ValuePrinter: strconv.Itoa, JustifyValue: true,
-		LabelWidth: statColWidth, ValueWidth: statTinyColWidth,
-	}
 	dsConfig := list.LabeledIntRowConfig{
 		ValuePrinter: RenderDeathSaves, JustifyValue: true,
 		LabelWidth: statColWidth, ValueWidth: statTinyColWidth,
 	}
 	rows := []list.Row{
-		list.NewLabeledIntRow(s.keymap, "AC", &s.agg.Character.ArmorClass,
-			editor.NewIntEditor(s.keymap, "AC", &s.agg.Character.ArmorClass)).WithConfig(standardCfg),
-		list.NewLabeledIntRow(s.keymap, "Initiative", &s.agg.Character.Initiative,
-			editor.NewIntEditor(s.keymap, "Initiative", &s.agg.Character.Initiative)).
-			WithConfig(list.LabeledIntRowConfig{
-				ValuePrinter: func(i int) string { return fmt.Sprintf("%+d", i) },
-				JustifyValue: true, LabelWidth: statColWidth, ValueWidth: statTinyColWidth,
-			}),
-		list.NewLabeledIntRow(s.keymap, "Speed", &s.agg.Character.Speed,
-			editor.NewIntEditor(s.keymap, "Speed", &s.agg.Character.Speed)).WithConfig(standardCfg),
-		list.NewStructRow(s.keymap, &HPInfo{&s.agg.Character.CurrHitPoints, &s.agg.Character.MaxHitPoints, &s.agg.Character.TempHitPoints}, renderHPInfoRow,
+		newArmorClassRow(s.keymap, s.agg),
+		newInitiativeRow(s.keymap, s.agg),
+		list.NewStructRow(s.keymap, s.agg, renderSpeedRow,
+			[]editor.ValueEditor{editor.NewIntEditor(s.keymap, "Speed", &s.agg.Character.Speed)}).
+			WithReader(renderEncumbrance),
+		list.NewStructRow(s.keymap, s.agg, renderHPRow,
 			[]editor.ValueEditor{
 				editor.NewIntEditor(s.keymap, "Current HP", &s.agg.Character.CurrHitPoints),
 				editor.NewIntEditor(s.keymap, "Max HP", &s.agg.Character.MaxHitPoints),
 				editor.NewIntEditor(s.keymap, "Temp HP", &s.agg.Character.TempHitPoints),
 			}),
-		list.NewStructRow(s.keymap, &HitDiceInfo{&s.agg.Character.UsedHitDice, &s.agg.Character.HitDice}, renderHitDiceInfoRow,
-			[]editor.ValueEditor{
-				editor.NewStringEditor(s.keymap, "Used Hit Dice", &s.agg.Character.UsedHitDice),
-				editor.NewStringEditor(s.keymap, "Hit Dice", &s.agg.Character.HitDice),
-			}),
+		list.NewStructRow(s.keymap, s.agg, renderHitDiceRow, nil).
+			WithEditAction(func(*repository.CharacterAggregate) tea.Cmd {
+				return command.SwitchScreenCmd(command.ClassScreenIndex)
+			}).
+			WithReader(renderHitDiceBreakdown),
 		list.NewLabeledIntRow(s.keymap, "DS Successes", &s.agg.Character.DeathSaveSuccesses,
 			editor.NewEnumEditor(s.keymap, styles.DeathSaveSymbols, "DS Successes", &s.agg.Character.DeathSaveSuccesses)).
 			WithConfig(dsConfig).WithCycleAction(cycleDeathSaves),
@@ -390,96 +430,219 @@ func (s *StatScreen) CreateSkillRows() {
 
 	for i := range s.agg.Skills {
 		skill := &s.agg.Skills[i]
-		row := list.NewStructRow(s.keymap, &SkillInfo{skill, s.agg.Abilities, &s.agg.Character.ProficiencyBonus}, renderSkillInfoRow,
+		row := list.NewStructRow(s.keymap, &SkillInfo{skill, s.agg}, renderSkillInfoRow,
 			[]editor.ValueEditor{
 				editor.NewEnumEditor(s.keymap, styles.ProficiencySymbols, "Proficiency", &skill.Proficiency),
 				editor.NewIntEditor(s.keymap, "Custom Modifier", &skill.CustomModifier),
-			})
+			}).
+			WithRollAction(func(info *SkillInfo) tea.Cmd { return command.RunQuickActionCmd("check " + info.skill.SkillName) })
 		rows = append(rows, row)
 	}
 
 	s.skills.WithRows(rows)
 }
 
+func (s *StatScreen) CreatePassiveRows() {
+	rows := make([]list.Row, 0, len(repository.PassiveSkills))
+	for _, skill := range repository.PassiveSkills {
+		rows = append(rows, newPassiveScoreRow(s.keymap, s.agg, skill))
+	}
+	s.passives.WithRows(rows)
+}
+
 func (s *StatScreen) CreateSavingThrowRows() {
-	renderer := renderSavingThrowInfoRow(s.agg.Abilities, s.agg.Character.ProficiencyBonus)
-	newSavingThrowRow := func(field *int, name string) list.Row {
-		return list.NewStructRow(s.keymap, &SavingThrowInfo{field, name}, renderer,
-			[]editor.ValueEditor{editor.NewEnumEditor(s.keymap, styles.ProficiencySymbols, "Proficiency", field)})
+	renderer := renderSavingThrowInfoRow(s.agg)
+	newSavingThrowRow := func(field *int, ability models.Ability) list.Row {
+		return list.NewStructRow(s.keymap, &SavingThrowInfo{field, ability}, renderer,
+			[]editor.ValueEditor{editor.NewEnumEditor(s.keymap, styles.ProficiencySymbols, "Proficiency", field)}).
+			WithRollAction(func(info *SavingThrowInfo) tea.Cmd { return command.RunQuickActionCmd("save " + info.ability.String()) })
 	}
 	s.savingThrows.WithRows([]list.Row{
-		newSavingThrowRow(&s.agg.SavingThrows.StrengthProficiency, "Strength"),
-		newSavingThrowRow(&s.agg.SavingThrows.DexterityProficiency, "Dexterity"),
-		newSavingThrowRow(&s.agg.SavingThrows.ConstitutionProficiency, "Constitution"),
-		newSavingThrowRow(&s.agg.SavingThrows.IntelligenceProficiency, "Intelligence"),
-		newSavingThrowRow(&s.agg.SavingThrows.WisdomProficiency, "Wisdom"),
-		newSavingThrowRow(&s.agg.SavingThrows.CharismaProficiency, "Charisma"),
+		newSavingThrowRow(&s.agg.SavingThrows.StrengthProficiency, models.Strength),
+		newSavingThrowRow(&s.agg.SavingThrows.DexterityProficiency, models.Dexterity),
+		newSavingThrowRow(&s.agg.SavingThrows.ConstitutionProficiency, models.Constitution),
+		newSavingThrowRow(&s.agg.SavingThrows.IntelligenceProficiency, models.Intelligence),
+		newSavingThrowRow(&s.agg.SavingThrows.WisdomProficiency, models.Wisdom),
+		newSavingThrowRow(&s.agg.SavingThrows.CharismaProficiency, models.Charisma),
 	})
 }
 
 // screen specific types + utility functions
 
-type HPInfo struct {
-	current *int
-	max     *int
-	temp    *int
+// renderHPRow shows the hit point maximum after exhaustion.
+func renderHPRow(a *repository.CharacterAggregate) string {
+	c := a.Character
+	tmp := ""
+	if c.TempHitPoints > 0 {
+		tmp = fmt.Sprintf("(+%d)", c.TempHitPoints)
+	}
+	return styles.RenderEdgeBound(statColWidth-4, 7, "HP", strconv.Itoa(c.CurrHitPoints)+tmp+"/"+strconv.Itoa(a.HitPointMaximum()))
 }
 
-func renderHPInfoRow(hp *HPInfo) string {
-	tmp := ""
-	if hp.temp != nil && *hp.temp > 0 {
-		tmp = fmt.Sprintf("(+%d)", *hp.temp)
+// renderSpeedRow shows the speed after the encumbrance and exhaustion
+// penalties.
+func renderSpeedRow(a *repository.CharacterAggregate) string {
+	if penalty := a.SpeedPenalty() + a.ExhaustionSpeedPenalty(); penalty > 0 {
+		return styles.RenderEdgeBound(statColWidth-6, statTinyColWidth+6, "Speed",
+			fmt.Sprintf("%d (-%d)", a.Speed(), penalty))
 	}
-	return styles.RenderEdgeBound(statColWidth-4, 7, "HP", strconv.Itoa(*hp.current)+tmp+"/"+strconv.Itoa(*hp.max))
+	return styles.RenderEdgeBound(statColWidth, statTinyColWidth, "Speed", strconv.Itoa(a.Speed()))
 }
 
-type HitDiceInfo struct {
-	current *string
-	max     *string
+// renderHitDiceRow shows the remaining hit dice per die size, e.g. 3/5d8.
+func renderHitDiceRow(a *repository.CharacterAggregate) string {
+	pools := []string{}
+	for _, p := range a.HitDice() {
+		pools = append(pools, fmt.Sprintf("%d/%dd%d", p.Remaining(), p.Total, p.Die))
+	}
+	return styles.RenderEdgeBound(statShortColWidth, statMediumColWidth, "Hit Dice", strings.Join(pools, " "))
 }
 
-func renderHitDiceInfoRow(hd *HitDiceInfo) string {
-	return styles.RenderEdgeBound(statShortColWidth, statMediumColWidth, "Hit Dice", *hd.current+"/"+*hd.max)
+func renderHitDiceBreakdown(a *repository.CharacterAggregate) string {
+	lines := []string{"Hit Dice", styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)}
+	for _, p := range a.HitDice() {
+		lines = append(lines, fmt.Sprintf("d%-3d %d of %d left", p.Die, p.Remaining(), p.Total))
+	}
+	lines = append(lines, "",
+		styles.GrayTextStyle.Render("Hit dice come from the class levels. Spend them with shortrest, a long rest regains half of them."))
+	return styles.DefaultTextStyle.
+		Width(styles.SmallScreenWidth - 4).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
 }
 
 type SavingThrowInfo struct {
 	proficiency *int
-	ability     string
+	ability     models.Ability
 }
 
-func renderSavingThrowInfoRow(a *models.AbilitiesTO, profBonus int) func(*SavingThrowInfo) string {
+func renderSavingThrowInfoRow(a *repository.CharacterAggregate) func(*SavingThrowInfo) string {
 	return func(s *SavingThrowInfo) string {
-		proficiency := models.Proficiency(*s.proficiency)
-		mod := models.ToModifier(a.ToScoreByName(
-			s.ability),
-			proficiency,
-			profBonus)
-
-		bullet := styles.ToSymbol(proficiency)
-		return styles.RenderEdgeBound(statLongColWidth, statTinyColWidth, bullet+" "+s.ability, fmt.Sprintf("%+d", mod))
+		bullet := styles.ToSymbol(models.Proficiency(*s.proficiency))
+		return styles.RenderEdgeBound(statLongColWidth, statTinyColWidth, bullet+" "+s.ability.String(),
+			fmt.Sprintf("%+d", a.SavingThrowModifier(s.ability)))
 	}
 }
 
 type SkillInfo struct {
-	skill     *models.CharacterSkillDetailTO
-	abilities *models.AbilitiesTO
-	profBonus *int
+	skill *models.CharacterSkillDetailTO
+	agg   *repository.CharacterAggregate
 }
 
 func renderSkillInfoRow(s *SkillInfo) string {
-	proficiency := models.Proficiency(s.skill.Proficiency)
-	mod := models.ToModifier(
-		s.abilities.ToScoreByName(s.skill.SkillAbility),
-		proficiency,
-		*s.profBonus) + s.skill.CustomModifier
-	bullet := styles.ToSymbol(proficiency)
-	return styles.RenderEdgeBound(statLongColWidth, statTinyColWidth, bullet+" "+s.skill.SkillName, fmt.Sprintf("%+d", mod))
+	bullet := styles.ToSymbol(models.Proficiency(s.skill.Proficiency))
+	return styles.RenderEdgeBound(statLongColWidth, statTinyColWidth, bullet+" "+s.skill.SkillName,
+		fmt.Sprintf("%+d", s.agg.SkillModifier(s.skill.SkillName)))
+}
+
+// newConcentrationRow toggles concentration, the reader names the spell.
+func newConcentrationRow(k util.KeyMap, agg *repository.CharacterAggregate) *list.StructRow[repository.CharacterAggregate] {
+	return list.NewStructRow(k, agg,
+		func(a *repository.CharacterAggregate) string {
+			label := lipgloss.NewStyle().Width(statStatusLabelWidth).Render("Concentration: ")
+			return label + styles.BinarySymbols[util.Clamp(a.Character.Concentration, 0, 1)].Label
+		},
+		[]editor.ValueEditor{
+			editor.NewEnumEditor(k, styles.BinarySymbols, "Concentration", &agg.Character.Concentration),
+			editor.NewStringEditor(k, "Spell", &agg.Character.ConcentrationSpell),
+		}).
+		WithCycleAction(func(a *repository.CharacterAggregate) tea.Cmd {
+			if a.Concentrating() {
+				a.EndConcentration()
+			} else {
+				a.Concentrate("")
+			}
+			return command.WriteBackRequest
+		}).
+		WithReader(renderConcentration)
+}
+
+func renderConcentration(a *repository.CharacterAggregate) string {
+	lines := []string{"Concentration", styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1)}
+	if a.Concentrating() {
+		lines = append(lines, "Concentrating on "+a.ConcentrationLabel())
+	} else {
+		lines = append(lines, styles.GrayTextStyle.Render("Not concentrating."))
+	}
+	lines = append(lines, "",
+		styles.GrayTextStyle.Render("Casting a concentration spell replaces it. Damage calls for a Con save "+
+			"of DC 10 or half the damage, concentration ends at 0 HP."))
+	return styles.DefaultTextStyle.
+		Width(styles.SmallScreenWidth - 4).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
+
+// newExhaustionRow shows the exhaustion level, the reader lists its effects
+// under the rules chosen for the character.
+func newExhaustionRow(k util.KeyMap, agg *repository.CharacterAggregate) *list.StructRow[repository.CharacterAggregate] {
+	return list.NewStructRow(k, agg,
+		func(a *repository.CharacterAggregate) string {
+			level := util.Clamp(a.Character.Exhaustion, 0, len(styles.ExhaustionSymbols)-1)
+			return "Exhaustion: " + styles.ExhaustionSymbols[level].Label
+		},
+		[]editor.ValueEditor{
+			editor.NewEnumEditor(k, styles.ExhaustionSymbols, "Exhaustion", &agg.Character.Exhaustion),
+			editor.NewEnumEditor(k, styles.ExhaustionRulesStrings, "Rules", &agg.Character.ExhaustionRules),
+		}).
+		WithCycleAction(func(a *repository.CharacterAggregate) tea.Cmd {
+			a.Character.Exhaustion = (a.Character.Exhaustion + 1) % len(styles.ExhaustionSymbols)
+			return command.WriteBackRequest
+		}).
+		WithReader(renderExhaustionEffects)
+}
+
+func renderExhaustionEffects(a *repository.CharacterAggregate) string {
+	rules := styles.ExhaustionRulesStrings[util.Clamp(a.Character.ExhaustionRules, 0, len(styles.ExhaustionRulesStrings)-1)].Label
+	lines := []string{
+		fmt.Sprintf("Exhaustion %d (%s)", a.Character.Exhaustion, rules),
+		styles.MakeHorizontalSeparator(styles.SmallScreenWidth-4, 1),
+	}
+	effects := a.ExhaustionEffects()
+	if len(effects) == 0 {
+		lines = append(lines, styles.GrayTextStyle.Render("No effects."))
+	}
+	lines = append(lines, effects...)
+	lines = append(lines, "",
+		styles.GrayTextStyle.Render("A long rest removes one level."))
+	return styles.DefaultTextStyle.
+		Width(styles.SmallScreenWidth - 4).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
 }
 
 func RenderAttack(a *models.AttackTO) string {
 	return fmt.Sprintf("%-11s %+3d %s (%s)", a.Name, a.Bonus, a.Damage, a.DamageType)
 }
 
+func rollAttack(a *models.AttackTO) tea.Cmd {
+	return quickaction.RollAttackCmd(a)
+}
+
+var rechargeAbbreviations = map[models.Recharge]string{
+	models.ShortRestRecharge: "SR",
+	models.LongRestRecharge:  "LR",
+	models.DawnRecharge:      "Dawn",
+}
+
+// renderResourceRow shows the remaining charges and when they recharge.
+func renderResourceRow(a *repository.CharacterAggregate) func(*models.ResourceTO) string {
+	return func(r *models.ResourceTO) string {
+		charges := fmt.Sprintf("%d/%d %4s", a.ResourceRemaining(r), a.ResourceMax(r), rechargeAbbreviations[models.Recharge(r.Recharge)])
+		return styles.RenderEdgeBound(statRightContentWidth-12, 12, r.Name, charges)
+	}
+}
+
+// cycleResource spends a charge, once all are spent they are regained.
+func cycleResource(a *repository.CharacterAggregate) func(*models.ResourceTO) tea.Cmd {
+	return func(r *models.ResourceTO) tea.Cmd {
+		if err := a.SpendResource(r); err != nil {
+			r.Used = 0
+		}
+		return command.WriteBackRequest
+	}
+}
+
 func RenderDeathSaves(amount int) string {
 	amount = util.Clamp(amount, 0, 3)
 	return styles.DeathSaveSymbols[amount].Label
@@ -40,6 +40,9 @@ type TitleScreen struct {
 
 	characters *list.List
 	nameInput  textinput.Model
+	profiles   []string
+	profile    string
+	readOnly   bool
 }
 
 func NewTitleScreen(km util.KeyMap) *TitleScreen {
@@ -63,6 +66,26 @@ func (t *TitleScreen) SetSummaries(s []models.CharacterSummary) {
 	t.characters.WithRows(charRows)
 }
 
+// SetProfiles sets the database profiles the user can cycle through.
+func (t *TitleScreen) SetProfiles(names []string, active string) {
+	t.profiles = names
+	t.profile = active
+}
+
+// SetReadOnly disables character creation.
+func (t *TitleScreen) SetReadOnly(readOnly bool) {
+	t.readOnly = readOnly
+}
+
+func (t *TitleScreen) nextProfile() string {
+	for i, p := range t.profiles {
+		if p == t.profile {
+			return t.profiles[(i+1)%len(t.profiles)]
+		}
+	}
+	return t.profiles[0]
+}
+
 func (m *TitleScreen) Init() tea.Cmd {
 	return command.LoadSummariesRequest
 }
@@ -93,6 +116,10 @@ func (m *TitleScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if msg, ok := msg.(tea.KeyPressMsg); ok && key.Matches(msg, m.KeyMap.Cycle) && len(m.profiles) > 1 {
+		return m, command.SwitchProfileRequest(m.nextProfile())
+	}
+
 	// Character selection
 	if m.characters.InFocus() {
 		switch msg.(type) {
@@ -113,7 +140,7 @@ func (m *TitleScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, m.KeyMap.Down):
 				m.characters.SetCursor(0)
 				m.characters.Focus()
-			case key.Matches(msg, m.KeyMap.Select):
+			case key.Matches(msg, m.KeyMap.Select) && !m.readOnly:
 				m.nameInput.Focus()
 				cmd = tea.Batch(textinput.Blink, util.EnterInsertModeCmd())
 			}
@@ -124,6 +151,9 @@ func (m *TitleScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *TitleScreen) View() tea.View {
 	createField := styles.RenderItem(!m.characters.InFocus(), "Create new Character")
+	if m.readOnly {
+		createField = styles.GrayTextStyle.Render("Create new Character (read-only)")
+	}
 
 	separator := styles.MakeHorizontalSeparator(titleScreenWidth/2, 1)
 
@@ -138,6 +168,13 @@ func (m *TitleScreen) View() tea.View {
 		"Press '" + styles.RenderKeyBinding(m.KeyMap.ShowKeymap) + "' to show key bindings",
 	)
 
+	notices := []string{helperNotice}
+	if len(m.profiles) > 1 {
+		notices = append([]string{styles.GrayTextStyle.Render(
+			"Profile: " + m.profile + " (press '" + styles.RenderKeyBinding(m.KeyMap.Cycle) + "' to switch)",
+		)}, notices...)
+	}
+
 	return tea.NewView(lipgloss.JoinVertical(lipgloss.Center,
 		lipgloss.NewStyle().Padding(1).Render(logo),
 		styles.DefaultBorderStyle.
@@ -145,7 +182,7 @@ func (m *TitleScreen) View() tea.View {
 			Height(titleScreenHeight).
 			Render(lipgloss.PlaceVertical(titleScreenHeight, lipgloss.Center,
 				lipgloss.JoinVertical(lipgloss.Center, createField, inputField, separator, chars))),
-		helperNotice))
+		lipgloss.JoinVertical(lipgloss.Center, notices...)))
 }
 
 // to fulfill FocusableModel interface
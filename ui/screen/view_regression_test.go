@@ -2,6 +2,7 @@ package screen
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"hostettler.dev/dnc/command"
@@ -17,6 +18,13 @@ func testAggregate() repository.CharacterAggregate {
 	return repository.TestCharacter(testID)
 }
 
+// pinLocalTime renders times in UTC for the rest of the test.
+func pinLocalTime(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+}
+
 func TestViewRegression(t *testing.T) {
 	km := util.DefaultKeyMap()
 	agg := testAggregate()
@@ -51,6 +59,44 @@ func TestViewRegression(t *testing.T) {
 		util.AssertGolden(t, "note_screen", s.View().Content)
 	})
 
+	t.Run("SessionLogScreen", func(t *testing.T) {
+		// event times render in local time; pin it so the golden is stable
+		pinLocalTime(t)
+		s := NewSessionLogScreen(km, &agg)
+		s.Init()
+		s.Focus()
+		util.AssertGolden(t, "session_log_screen", s.View().Content)
+	})
+
+	t.Run("RollLogScreen", func(t *testing.T) {
+		pinLocalTime(t)
+		s := NewRollLogScreen(km, &agg)
+		s.Init()
+		s.Focus()
+		util.AssertGolden(t, "roll_log_screen", s.View().Content)
+	})
+
+	t.Run("ClassScreen", func(t *testing.T) {
+		s := NewClassScreen(km, &agg)
+		s.Init()
+		s.Focus()
+		util.AssertGolden(t, "class_screen", s.View().Content)
+	})
+
+	t.Run("ConditionScreen", func(t *testing.T) {
+		s := NewConditionScreen(km, &agg)
+		s.Init()
+		s.Focus()
+		util.AssertGolden(t, "condition_screen", s.View().Content)
+	})
+
+	t.Run("MacroScreen", func(t *testing.T) {
+		s := NewMacroScreen(km, &agg)
+		s.Init()
+		s.Focus()
+		util.AssertGolden(t, "macro_screen", s.View().Content)
+	})
+
 	t.Run("TitleScreen", func(t *testing.T) {
 		s := NewTitleScreen(km)
 		s.SetSummaries([]models.CharacterSummary{
@@ -59,6 +105,15 @@ func TestViewRegression(t *testing.T) {
 		util.AssertGolden(t, "title_screen", s.View().Content)
 	})
 
+	t.Run("TitleScreenWithProfiles", func(t *testing.T) {
+		s := NewTitleScreen(km)
+		s.SetSummaries([]models.CharacterSummary{
+			{ID: testID, Name: "Bobby"},
+		})
+		s.SetProfiles([]string{"default", "main", "one-shots"}, "main")
+		util.AssertGolden(t, "title_screen_profiles", s.View().Content)
+	})
+
 	t.Run("ConfirmationScreen", func(t *testing.T) {
 		s := NewConfirmationScreen(km)
 		s.Init()
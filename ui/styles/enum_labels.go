@@ -59,9 +59,85 @@ var ExhaustionSymbols []EnumMapping = []EnumMapping{
 	{Value: 6, Label: "■■■■■■"},
 }
 
+var ExhaustionRulesStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.TieredExhaustion), Label: "2014, tiered"},
+	{Value: int(models.PenaltyExhaustion), Label: "2024, -2 per level"},
+}
+
 var DeathSaveSymbols []EnumMapping = []EnumMapping{
 	{Value: 0, Label: "○○○"},
 	{Value: 1, Label: "●○○"},
 	{Value: 2, Label: "●●○"},
 	{Value: 3, Label: "●●●"},
 }
+
+var SpellcastingStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.NoSpellcasting), Label: "None"},
+	{Value: int(models.FullCaster), Label: "Full Caster"},
+	{Value: int(models.HalfCaster), Label: "Half Caster"},
+	{Value: int(models.ThirdCaster), Label: "Third Caster"},
+	{Value: int(models.PactMagic), Label: "Pact Magic"},
+}
+
+var HitDieStrings []EnumMapping = []EnumMapping{
+	{Value: 6, Label: "d6"},
+	{Value: 8, Label: "d8"},
+	{Value: 10, Label: "d10"},
+	{Value: 12, Label: "d12"},
+}
+
+var AbilityStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.NoAbility), Label: "None"},
+	{Value: int(models.Strength), Label: "Str"},
+	{Value: int(models.Dexterity), Label: "Dex"},
+	{Value: int(models.Constitution), Label: "Con"},
+	{Value: int(models.Intelligence), Label: "Int"},
+	{Value: int(models.Wisdom), Label: "Wis"},
+	{Value: int(models.Charisma), Label: "Cha"},
+}
+
+var UnarmoredDefenseStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.StandardDefense), Label: "10 + Dex"},
+	{Value: int(models.BarbarianDefense), Label: "10 + Dex + Con"},
+	{Value: int(models.MonkDefense), Label: "10 + Dex + Wis"},
+	{Value: int(models.NaturalArmor), Label: "13 + Dex"},
+}
+
+var EncumbranceStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.Unencumbered), Label: "Unencumbered"},
+	{Value: int(models.Encumbered), Label: "Encumbered"},
+	{Value: int(models.HeavilyEncumbered), Label: "Heavily Encumbered"},
+	{Value: int(models.OverCapacity), Label: "Over Capacity"},
+}
+
+var ResourceFormulaStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.FixedMax), Label: "Fixed"},
+	{Value: int(models.LevelMax), Label: "Level"},
+	{Value: int(models.AbilityModifierMax), Label: "Ability Modifier"},
+	{Value: int(models.ProficiencyBonusMax), Label: "Proficiency Bonus"},
+}
+
+var RechargeStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.ShortRestRecharge), Label: "Short Rest"},
+	{Value: int(models.LongRestRecharge), Label: "Long Rest"},
+	{Value: int(models.DawnRecharge), Label: "Dawn"},
+}
+
+// ConditionStrings starts with custom conditions, the rest are the known ones.
+var ConditionStrings []EnumMapping = []EnumMapping{
+	{Value: int(models.CustomCondition), Label: "Custom"},
+	{Value: int(models.Blinded), Label: "Blinded"},
+	{Value: int(models.Charmed), Label: "Charmed"},
+	{Value: int(models.Deafened), Label: "Deafened"},
+	{Value: int(models.Frightened), Label: "Frightened"},
+	{Value: int(models.Grappled), Label: "Grappled"},
+	{Value: int(models.Incapacitated), Label: "Incapacitated"},
+	{Value: int(models.Invisible), Label: "Invisible"},
+	{Value: int(models.Paralyzed), Label: "Paralyzed"},
+	{Value: int(models.Petrified), Label: "Petrified"},
+	{Value: int(models.Poisoned), Label: "Poisoned"},
+	{Value: int(models.Prone), Label: "Prone"},
+	{Value: int(models.Restrained), Label: "Restrained"},
+	{Value: int(models.Stunned), Label: "Stunned"},
+	{Value: int(models.Unconscious), Label: "Unconscious"},
+}
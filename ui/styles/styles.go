@@ -2,6 +2,8 @@ package styles
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -134,6 +136,15 @@ func RenderEdgeBound(w1 int, w2 int, str1 string, str2 string) string {
 	return fmt.Sprintf(format, str1, str2)
 }
 
+// DerivedMarker flags values computed from the rest of the sheet, the blank
+// keeps overridden values aligned with them.
+func DerivedMarker(derived bool) string {
+	if derived {
+		return GrayTextStyle.Render("*")
+	}
+	return " "
+}
+
 func RenderLeftBound(w1 int, str1 string, str2 string) string {
 	format := fmt.Sprintf("%%-%ds %%s", w1)
 	return fmt.Sprintf(format, str1, str2)
@@ -160,6 +171,20 @@ func PrettySpellSlots(used int, cap int) string {
 	return DefaultTextStyle.Render(s)
 }
 
+// PrettyWeight rounds to a tenth of a pound.
+func PrettyWeight(lb float64) string {
+	return strconv.FormatFloat(math.Round(lb*10)/10, 'f', -1, 64)
+}
+
+// PrettyLoadBar fills width cells in proportion to the carried weight.
+func PrettyLoadBar(weight float64, capacity float64, width int) string {
+	filled := width
+	if capacity > 0 {
+		filled = min(width, int(math.Ceil(weight/capacity*float64(width))))
+	}
+	return strings.Repeat("■", filled) + strings.Repeat("□", width-filled)
+}
+
 func PrettyAttunementSlots(used int) string {
 	if used == 0 {
 		return ""
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -37,16 +38,81 @@ func encode(f *os.File, v any) error {
 }
 
 type Config struct {
-	KeyMap       KeyMap `json:"keymap"`
+	KeyMap       KeyMap             `json:"keymap"`
+	DatabasePath string             `json:"database_path"`
+	Profile      string             `json:"profile"`
+	Profiles     map[string]Profile `json:"profiles"`
+	VimMode      bool               `json:"vim_mode"`
+	ReadOnly     bool               `json:"-"`
+	Demo         bool               `json:"-"`
+}
+
+// DefaultProfileName refers to the database at Config.DatabasePath.
+const DefaultProfileName = "default"
+
+// Profile is a named database, e.g. one per campaign.
+type Profile struct {
+	// DatabasePath defaults to <name>.db next to the default database.
 	DatabasePath string `json:"database_path"`
-	VimMode      bool   `json:"vim_mode"`
-	Demo         bool   `json:"-"`
+	ReadOnly     bool   `json:"read_only"`
+}
+
+// ProfileNames returns the default profile followed by all configured
+// profiles in alphabetical order.
+func (c Config) ProfileNames() []string {
+	names := []string{DefaultProfileName}
+	for name := range c.Profiles {
+		if name != DefaultProfileName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// ActiveProfile returns the selected profile name.
+func (c Config) ActiveProfile() string {
+	if c.Profile == "" {
+		return DefaultProfileName
+	}
+	return c.Profile
+}
+
+// ProfileDatabasePath resolves a profile name to its database file.
+func (c Config) ProfileDatabasePath(name string) (string, error) {
+	if name == "" || name == DefaultProfileName {
+		return c.DatabasePath, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return "", fmt.Errorf("unknown profile %q", name)
+	}
+	if p.DatabasePath == "" {
+		return filepath.Join(filepath.Dir(c.DatabasePath), name+".db"), nil
+	}
+	return p.DatabasePath, nil
+}
+
+// ProfileReadOnly reports whether the profile's database must not be modified,
+// either because of its settings or because of --read-only.
+func (c Config) ProfileReadOnly(name string) bool {
+	if name == "" {
+		name = DefaultProfileName
+	}
+	return c.ReadOnly || c.Profiles[name].ReadOnly
+}
+
+// ActiveDatabasePath resolves the selected profile to its database file.
+func (c Config) ActiveDatabasePath() (string, error) {
+	return c.ProfileDatabasePath(c.Profile)
 }
 
 func DefaultConfig(cfgDir string) Config {
 	return Config{
 		KeyMap:       DefaultKeyMap(),
 		DatabasePath: filepath.Join(cfgDir, "dnc", "dnc.db"),
+		Profile:      DefaultProfileName,
+		Profiles:     map[string]Profile{},
 		VimMode:      false,
 		Demo:         false,
 	}
@@ -107,6 +173,8 @@ func LoadDemoConfig(cfgDir string) (Config, func(), error) {
 		return Config{}, func() {}, err
 	}
 	cfg.DatabasePath = filepath.Join(tmp, "demo.db")
+	cfg.Profile = ""
+	cfg.Profiles = nil
 	cfg.Demo = true
 	cfg.VimMode = false
 	cleanup := func() {
@@ -146,6 +214,7 @@ type KeyMap struct {
 	ScreenDown    key.Binding `json:"screen_down"`
 	ShowKeymap    key.Binding `json:"show_keymap"`
 	Cycle         key.Binding `json:"cycle"`
+	Roll          key.Binding `json:"roll"`
 	QuickAction   key.Binding `json:"quick_action"`
 	TextSearch    key.Binding `json:"text_search"`
 	NextMatch     key.Binding `json:"next_match"`
@@ -184,6 +253,7 @@ func DefaultKeyMap() KeyMap {
 		ScreenDown:    key.NewBinding(key.WithKeys("pgdown")),
 		ShowKeymap:    key.NewBinding(key.WithKeys("ctrl+h")),
 		Cycle:         key.NewBinding(key.WithKeys("tab")),
+		Roll:          key.NewBinding(key.WithKeys("r")),
 		QuickAction:   key.NewBinding(key.WithKeys(":")),
 		TextSearch:    key.NewBinding(key.WithKeys("/")),
 		NextMatch:     key.NewBinding(key.WithKeys("n")),
@@ -200,6 +270,16 @@ func DefaultKeyMap() KeyMap {
 	}
 }
 
+// ReadOnlyKeyMap returns a copy of km with the bindings that modify data
+// (editing, appending, deleting and cycling values) disabled. Rolls stay
+// enabled, they are not logged.
+func ReadOnlyKeyMap(km KeyMap) KeyMap {
+	for _, b := range []*key.Binding{&km.Edit, &km.Append, &km.Delete, &km.Cycle} {
+		b.SetEnabled(false)
+	}
+	return km
+}
+
 type keyBindingDTO struct {
 	Keys []string `json:"keys,omitempty"`
 }
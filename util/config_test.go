@@ -63,3 +63,59 @@ func TestCreatesMissingConfig(t *testing.T) {
 		t.Fatalf("Could not remove the test config: %v", err)
 	}
 }
+
+func TestProfileResolution(t *testing.T) {
+	cfg := DefaultConfig("/cfg")
+	cfg.Profiles = map[string]Profile{
+		"one-shots": {},
+		"main":      {DatabasePath: "/campaigns/main.db"},
+	}
+
+	if got := cfg.ProfileNames(); !reflect.DeepEqual(got, []string{"default", "main", "one-shots"}) {
+		t.Errorf("Unexpected profile order: %v", got)
+	}
+	cases := map[string]string{
+		"":          "/cfg/dnc/dnc.db",
+		"default":   "/cfg/dnc/dnc.db",
+		"main":      "/campaigns/main.db",
+		"one-shots": "/cfg/dnc/one-shots.db",
+	}
+	for name, want := range cases {
+		got, err := cfg.ProfileDatabasePath(name)
+		if err != nil {
+			t.Errorf("Resolving profile %q failed: %v", name, err)
+		} else if got != want {
+			t.Errorf("Profile %q: expected %s, got %s", name, want, got)
+		}
+	}
+	if _, err := cfg.ProfileDatabasePath("missing"); err == nil {
+		t.Errorf("Expected an error for an unknown profile")
+	}
+}
+
+func TestProfileReadOnly(t *testing.T) {
+	cfg := DefaultConfig("/cfg")
+	cfg.Profiles = map[string]Profile{
+		"shared": {ReadOnly: true},
+		"main":   {},
+	}
+	if cfg.ProfileReadOnly("") || cfg.ProfileReadOnly("main") || !cfg.ProfileReadOnly("shared") {
+		t.Errorf("Read-only setting not applied per profile")
+	}
+	cfg.ReadOnly = true
+	if !cfg.ProfileReadOnly("main") {
+		t.Errorf("Expected --read-only to apply to every profile")
+	}
+}
+
+func TestReadOnlyKeyMapDisablesWrites(t *testing.T) {
+	km := ReadOnlyKeyMap(DefaultKeyMap())
+	for name, b := range map[string]key.Binding{"edit": km.Edit, "append": km.Append, "delete": km.Delete, "cycle": km.Cycle} {
+		if b.Enabled() {
+			t.Errorf("Expected %s to be disabled", name)
+		}
+	}
+	if !km.Show.Enabled() || !km.Roll.Enabled() || !DefaultKeyMap().Edit.Enabled() {
+		t.Errorf("Expected other bindings and the original keymap to be unaffected")
+	}
+}